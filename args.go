@@ -0,0 +1,26 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// parseRuleOptions separates "key=value" options from positional arguments, e.g.
+// "duration:min=1s,max=1h" or "url:https,max=2048". Option keys must be listed in allowed.
+func parseRuleOptions(rule string, args []string, allowed ...string) (positional []string, options map[string]string, err error) {
+	options = make(map[string]string)
+	for _, arg := range args {
+		key, value, found := strings.Cut(arg, "=")
+		if !found {
+			positional = append(positional, arg)
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !slices.Contains(allowed, key) {
+			return nil, nil, fmt.Errorf("unknown %s option: %s", rule, key)
+		}
+		options[key] = strings.TrimSpace(value)
+	}
+	return positional, options, nil
+}
@@ -0,0 +1,52 @@
+package validation
+
+import "context"
+
+// BatchResult reports the validation of a batch of rows, see Factory.MakeBatch.
+type BatchResult struct {
+	Passed int
+	Failed int
+	// Errors holds the errors of the fields of each invalid row by its index in the batch.
+	Errors map[int]*ErrorBag
+	// Valid holds the valid rows in their original order.
+	Valid []map[string]interface{}
+}
+
+// MakeBatch parses rules and validates each row with them, e.g. for CSV or bulk import endpoints.
+// Rows are decoded data flattened like by ValidateData. It returns an error when the rules cannot be
+// parsed or a row is rejected as a whole, see ValidateBatch.
+func (f *Factory) MakeBatch(rows []map[string]interface{}, rules map[string]string) (*BatchResult, error) {
+	validator, err := f.Parse(rules)
+	if err != nil {
+		return nil, err
+	}
+	return validator.ValidateBatch(rows)
+}
+
+// ValidateBatch validates each row on its own with the validator's rules, collecting the errors of
+// every field of a row like ValidateAll. It stops with the error of a row rejected as a whole, e.g.
+// an *ErrLimitExceeded.
+func (v *Validator) ValidateBatch(rows []map[string]interface{}) (*BatchResult, error) {
+	result := &BatchResult{Errors: make(map[int]*ErrorBag)}
+	index := 0
+	failed, err := v.ValidateDataStreamContext(context.Background(), func() (map[string]interface{}, bool) {
+		if index == len(rows) {
+			return nil, false
+		}
+		index++
+		return rows[index-1], true
+	}, func(i int, errors *ErrorBag) {
+		result.Errors[i] = errors
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, row := range rows {
+		if _, invalid := result.Errors[i]; !invalid {
+			result.Valid = append(result.Valid, row)
+		}
+	}
+	result.Failed = failed
+	result.Passed = len(result.Valid)
+	return result, nil
+}
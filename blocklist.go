@@ -0,0 +1,131 @@
+package validation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// blocklistLeet maps the digits and symbols commonly standing for letters in evasions such as "b4d".
+var blocklistLeet = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s")
+
+// blocklistWords returns the words of str as the not_in_blocklist rule compares them: accents
+// removed, case folded, digits and symbols standing for letters replaced, and split on everything
+// else, so "B4d_Wörd" gives ["bad", "word"].
+func blocklistWords(str string) []string {
+	str = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, norm.NFKD.String(str))
+	return strings.FieldsFunc(blocklistLeet.Replace(str), func(r rune) bool { return !unicode.IsLetter(r) })
+}
+
+// RegisterBlocklist adds a named list of blocked words, replacing the one of the same name, for the
+// not_in_blocklist rule of validators parsed afterwards. Entries are normalized like the values they
+// are compared with; an entry of several words only matches them together.
+func (f *Factory) RegisterBlocklist(name string, words []string) {
+	lists, _ := f.config["blocklists"].(map[string][]string)
+	if lists == nil {
+		lists = make(map[string][]string)
+		f.SetConfig("blocklists", lists)
+	}
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		if entry := strings.Join(blocklistWords(word), ""); entry != "" && !slices.Contains(normalized, entry) {
+			normalized = append(normalized, entry)
+		}
+	}
+	lists[name] = normalized
+}
+
+// LoadBlocklist registers a blocklist read from r, one entry per line. Blank lines and lines starting
+// with # are skipped.
+func (f *Factory) LoadBlocklist(name string, r io.Reader) error {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read blocklist %s: %w", name, err)
+	}
+	f.RegisterBlocklist(name, words)
+	return nil
+}
+
+// LoadBlocklistFile is like LoadBlocklist with the entries read from the file at path.
+func (f *Factory) LoadBlocklistFile(name string, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to read blocklist %s: %w", name, err)
+	}
+	defer file.Close()
+	return f.LoadBlocklist(name, file)
+}
+
+// containsWordRun reports whether consecutive words, joined, equal entry.
+func containsWordRun(words []string, entry string) bool {
+	for i := range words {
+		run := ""
+		for _, word := range words[i:] {
+			if run += word; len(run) >= len(entry) {
+				if run == entry {
+					return true
+				}
+				break
+			}
+		}
+	}
+	return false
+}
+
+// not_in_blocklist:listname
+// not_in_blocklist:listname,match=word
+// The field under validation must not contain an entry of the named blocklist registered on the
+// factory (see Factory.RegisterBlocklist), compared after normalization (see blocklistWords) and
+// ignoring the separators between words, so "Bad.W0rd" and "xxbadwordxx" contain "bad word". With
+// match=word an entry must be whole words of the value, which avoids rejecting names that merely
+// contain one. The message does not reveal the entry.
+func constructNotInBlocklist(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	positional, options, err := parseRuleOptions("not_in_blocklist", args, "match")
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 1 || strings.TrimSpace(positional[0]) == "" {
+		return nil, fmt.Errorf("not_in_blocklist rule requires a list name")
+	}
+	name := strings.TrimSpace(positional[0])
+	entries, ok := cfg["blocklists"].(map[string][]string)[name]
+	if !ok {
+		return nil, fmt.Errorf("not_in_blocklist rule has unknown list %q", name)
+	}
+	wholeWords := false
+	switch match := strings.ToLower(options["match"]); match {
+	case "", "contains":
+	case "word":
+		wholeWords = true
+	default:
+		return nil, fmt.Errorf("invalid not_in_blocklist match: %s", match)
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		words := blocklistWords(ctx.FieldValue)
+		joined := strings.Join(words, "")
+		for _, entry := range entries {
+			if wholeWords && containsWordRun(words, entry) || !wholeWords && strings.Contains(joined, entry) {
+				return false, fmt.Errorf("the %s field contains a word that is not allowed", ctx.FieldName)
+			}
+		}
+		return true, nil
+	}, nil
+}
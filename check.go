@@ -0,0 +1,146 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fieldReferences returns the other fields referenced by the arguments of the rules referring to
+// other fields, for CheckRules.
+var fieldReferences = map[string]func(args []string) []string{
+	"same":                 firstArg,
+	"different":            firstArg,
+	"distinct_with":        allArgs,
+	"not_similar_to":       firstArg,
+	"accepted_if":          firstArg,
+	"declined_if":          firstArg,
+	"required_if":          firstArg,
+	"required_if_accepted": allArgs,
+	"required_if_declined": allArgs,
+	"required_unless":      firstArg,
+	"exclude_if":           firstArg,
+	"exclude_unless":       firstArg,
+	"missing_if":           firstArg,
+	"missing_unless":       firstArg,
+	"missing_with":         allArgs,
+	"missing_with_all":     allArgs,
+	"present_if":           firstArg,
+	"prohibits":            allArgs,
+	"present_unless":       firstArg,
+	"postal_code_with":     firstArg,
+	"required_with":        allArgs,
+	"required_with_all":    allArgs,
+	"required_without":     allArgs,
+	"required_without_all": allArgs,
+	"present_with":         allArgs,
+	"present_with_all":     allArgs,
+	"after":                fieldOrDate,
+	"after_or_equal":       fieldOrDate,
+	"before":               fieldOrDate,
+	"before_or_equal":      fieldOrDate,
+	"date_equals":          fieldOrDate,
+	"within_window":        windowFields,
+	"gt":                   fieldOrNumber,
+	"gte":                  fieldOrNumber,
+	"lt":                   fieldOrNumber,
+	"lte":                  fieldOrNumber,
+}
+
+func firstArg(args []string) []string {
+	return args[:min(len(args), 1)]
+}
+
+func allArgs(args []string) []string {
+	return args
+}
+
+func fieldOrNumber(args []string) []string {
+	if len(args) > 0 && isNumeric(args[0]) {
+		return nil
+	}
+	return firstArg(args)
+}
+
+func fieldOrDate(args []string) []string {
+	if len(args) == 0 || strings.Contains(args[0], "=") {
+		return nil
+	}
+	if _, ok := parseDate(strings.TrimSpace(args[0]), defaultDateFormats, time.UTC); ok {
+		return nil
+	}
+	return firstArg(args)
+}
+
+// windowFields returns the bounds of within_window that are neither dates nor times of day.
+func windowFields(args []string) []string {
+	var fields []string
+	for _, arg := range args[:min(len(args), 2)] {
+		arg = strings.TrimSpace(arg)
+		if strings.Contains(arg, "=") {
+			continue
+		}
+		if _, ok := parseClock(arg); ok {
+			continue
+		}
+		if _, ok := parseDate(arg, defaultDateFormats, time.UTC); !ok {
+			fields = append(fields, arg)
+		}
+	}
+	return fields
+}
+
+// CheckRules parses rules without validating any data and reports every problem found, instead of the
+// first one as Parse does: unknown rules, rejected arguments (missing arguments, bad regexes, ...) and
+// references to fields having no rules, e.g. "same:pasword". Run it at startup or in tests to catch
+// misconfigured rules. Errors are sorted by field, then in the order of the rules.
+func (f *Factory) CheckRules(rules map[string]string) []RuleError {
+	fields := make([]string, 0, len(rules))
+	for field := range rules {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	var errs []RuleError
+	for _, field := range fields {
+		expanded, err := f.expandRuleSets(strings.Split(rules[field], "|"), nil)
+		if err != nil {
+			errs = append(errs, RuleError{Field: field, Rule: rules[field], Err: err})
+			continue
+		}
+		for _, rule := range expanded {
+			if strings.TrimSpace(rule) == "" {
+				continue
+			}
+			if _, err := f.Parse(map[string]string{field: rule}); err != nil {
+				errs = append(errs, RuleError{Field: field, Rule: rule, Err: err})
+				continue
+			}
+			name, argString, _ := strings.Cut(rule, ":")
+			references, ok := fieldReferences[strings.ToLower(strings.TrimSpace(name))]
+			if !ok || argString == "" {
+				continue
+			}
+			for _, reference := range references(strings.Split(argString, ",")) {
+				if !declaresField(rules, reference) {
+					errs = append(errs, RuleError{Field: field, Rule: rule, Err: fmt.Errorf("field %s has no rules", reference)})
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// declaresField reports whether rules has a field matching reference, either as written or through a
+// wildcard field.
+func declaresField(rules map[string]string, reference string) bool {
+	if _, ok := rules[reference]; ok {
+		return true
+	}
+	for field := range rules {
+		if strings.Contains(field, "*") && matchesWildcard(field, reference) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package validation
+
+import "maps"
+
+// WithContextValue returns a copy of the validator carrying a value from outside the input, such as
+// the role of the current user or a route parameter, for rules like required_if_context:user_role,admin.
+// The validator itself is left unchanged, so a validator shared between requests can be specialized
+// per request: validator.WithContextValue("user_role", role).Validate(data).
+func (v *Validator) WithContextValue(key string, value string) *Validator {
+	clone := *v
+	clone.values = maps.Clone(v.values)
+	if clone.values == nil {
+		clone.values = make(map[string]string)
+	}
+	clone.values[key] = value
+	return &clone
+}
+
+// ContextValue returns a value set with WithContextValue on the running validator.
+func (ctx *ValidationContext) ContextValue(key string) (string, bool) {
+	if ctx.validator == nil {
+		return "", false
+	}
+	value, ok := ctx.validator.values[key]
+	return value, ok
+}
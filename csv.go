@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RowError is an invalid CSV row: Line is its line in the input (1 is the header row), and the
+// ErrorBag holds the errors of every invalid field of the row, e.g. e.Get("email").
+type RowError struct {
+	Line int
+	*ErrorBag
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Join("; "))
+}
+
+func (e RowError) Unwrap() error {
+	return e.ErrorBag
+}
+
+// CSV coercion hints, applied to a column before its rules run.
+var csvCoercions = map[string]func(string) string{
+	// trim surrounding whitespace
+	"trim": strings.TrimSpace,
+	// trim and drop thousands separators and spaces, e.g. " 1 234" or "1_000" become "1234" and "1000"
+	"number": func(str string) string {
+		return strings.NewReplacer(" ", "", "_", "", "'", "").Replace(strings.TrimSpace(str))
+	},
+	// map the usual spreadsheet spellings (TRUE, Yes, y, 1, ...) to "true" and "false"
+	"boolean": func(str string) string {
+		switch strings.ToLower(strings.TrimSpace(str)) {
+		case "true", "yes", "y", "1", "on":
+			return "true"
+		case "false", "no", "n", "0", "off":
+			return "false"
+		}
+		return str
+	},
+}
+
+// ValidateCSV reads CSV from r, whose header row gives the field names, and validates each record
+// with the validator's rules, one record at a time. hints maps columns to a coercion applied first:
+// "trim", "number" or "boolean". onError receives each invalid row with the errors of its fields. It
+// returns the number of invalid rows, and an error when the input is not valid CSV, a hint is unknown
+// or a row is rejected as a whole, e.g. with an *ErrLimitExceeded.
+func (v *Validator) ValidateCSV(r io.Reader, hints map[string]string, onError func(RowError)) (int, error) {
+	return v.ValidateCSVContext(context.Background(), r, hints, onError)
+}
+
+// ValidateCSVContext is like ValidateCSV and stops with the context error once ctx is done.
+func (v *Validator) ValidateCSVContext(ctx context.Context, r io.Reader, hints map[string]string, onError func(RowError)) (int, error) {
+	coercions := make(map[string]func(string) string, len(hints))
+	for column, hint := range hints {
+		coerce, ok := csvCoercions[strings.ToLower(strings.TrimSpace(hint))]
+		if !ok {
+			return 0, fmt.Errorf("unknown csv hint for %s: %s", column, hint)
+		}
+		coercions[column] = coerce
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var readErr error
+	line := 0
+	next := func() (map[string]string, bool) {
+		record, err := reader.Read()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				readErr = err
+			}
+			return nil, false
+		}
+		line, _ = reader.FieldPos(0)
+		row := make(map[string]string, len(header))
+		for i, value := range record {
+			if i >= len(header) {
+				break
+			}
+			if coerce, ok := coercions[header[i]]; ok {
+				value = coerce(value)
+			}
+			row[header[i]] = value
+		}
+		return row, true
+	}
+	failed, err := v.ValidateStreamContext(ctx, next, func(_ int, bag *ErrorBag) {
+		if onError != nil {
+			onError(RowError{Line: line, ErrorBag: bag})
+		}
+	})
+	if err != nil {
+		return failed, err
+	}
+	return failed, readErr
+}
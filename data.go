@@ -0,0 +1,424 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Value types reported by ValidationContext.Type for input given to ValidateData. Input given to
+// Validate is made of strings only and always has the type TypeString.
+const (
+	TypeString   = "string"
+	TypeStringer = "stringer" // a fmt.Stringer other than a string, e.g. a time.Time
+	TypeNumber   = "number"
+	TypeBoolean  = "boolean"
+	TypeNull     = "null"
+	TypeArray    = "array"
+	TypeMap      = "map"
+	TypeOther    = "other"
+)
+
+// ValidateData validates decoded data such as the result of json.Unmarshal into a map. Nested maps and
+// slices are flattened into dot separated keys ("items.0.name") and the other values are formatted as
+// strings, so the rules apply as with Validate, while the original type of each value remains
+// available to the rules as ValidationContext.Type, e.g. for the string rule.
+func (v *Validator) ValidateData(data map[string]interface{}) error {
+	return v.ValidateDataContext(context.Background(), data)
+}
+
+// ValidateDataContext is like ValidateData but carries ctx to the rules, as ValidateContext does.
+func (v *Validator) ValidateDataContext(ctx context.Context, data map[string]interface{}) error {
+	flat := v.newFlattened()
+	for key, item := range data {
+		flat.add(key, item)
+	}
+	return flat.validate(ctx, v)
+}
+
+// ValidateStruct validates a struct or a pointer to one. Its exported fields are flattened like maps by
+// ValidateData, under the name given by their json tag or else the field name, nested structs giving
+// dot separated keys ("address.city"). Zero values are kept, so dependent rules such as same:password
+// see every field; fields tagged json:"-" are skipped and embedded structs without a tag are promoted.
+// A value that contains itself, such as a linked list node pointing back to itself, fails validation.
+func (v *Validator) ValidateStruct(value interface{}) error {
+	return v.ValidateStructContext(context.Background(), value)
+}
+
+// ValidateStructContext is like ValidateStruct but carries ctx to the rules, as ValidateContext does.
+func (v *Validator) ValidateStructContext(ctx context.Context, value interface{}) error {
+	reflected := reflect.Indirect(reflect.ValueOf(value))
+	if reflected.Kind() != reflect.Struct {
+		return fmt.Errorf("ValidateStruct requires a struct, got %T", value)
+	}
+	flat := v.newFlattened()
+	flat.addStruct("", reflected)
+	return flat.validate(ctx, v)
+}
+
+// ValidateItems validates a slice of maps or structs, such as a []map[string]interface{} decoded from
+// a JSON array, with rules keyed by "*.field". Each element is flattened under its index ("0.email")
+// as ValidateData does for nested slices.
+func (v *Validator) ValidateItems(items interface{}) error {
+	return v.ValidateItemsContext(context.Background(), items)
+}
+
+// ValidateItemsContext is like ValidateItems but carries ctx to the rules, as ValidateContext does.
+func (v *Validator) ValidateItemsContext(ctx context.Context, items interface{}) error {
+	reflected := reflect.Indirect(reflect.ValueOf(items))
+	if reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array {
+		return fmt.Errorf("ValidateItems requires a slice, got %T", items)
+	}
+	flat := v.newFlattened()
+	for i := 0; i < reflected.Len(); i++ {
+		flat.add(strconv.Itoa(i), reflected.Index(i).Interface())
+	}
+	return flat.validate(ctx, v)
+}
+
+// StringKeys deep-converts data decoded from YAML, whose maps are map[interface{}]interface{}, to the
+// map[string]interface{} taken by ValidateData. Keys are formatted with fmt.Sprint, so a key 1 becomes
+// "1", and nested maps are converted inside maps and slices as well. Nested maps with non-string keys
+// are also accepted as-is by ValidateData, only the top-level map needs the conversion.
+func StringKeys(data map[interface{}]interface{}) map[string]interface{} {
+	converted := make(map[string]interface{}, len(data))
+	for key, item := range data {
+		converted[fmt.Sprint(key)] = stringKeysValue(item)
+	}
+	return converted
+}
+
+func stringKeysValue(item interface{}) interface{} {
+	switch typed := item.(type) {
+	case map[interface{}]interface{}:
+		return StringKeys(typed)
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, value := range typed {
+			converted[key] = stringKeysValue(value)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(typed))
+		for i, value := range typed {
+			converted[i] = stringKeysValue(value)
+		}
+		return converted
+	}
+	return item
+}
+
+// Sizer is implemented by values given to ValidateData or ValidateStruct that measure their own size
+// for the size rules (size, min, max, between, gt, ...), e.g. a money amount or a file.
+type Sizer interface {
+	Size() float64
+}
+
+type sizesKey struct{}
+
+// withSizes carries the sizes of the Sizer values of the input to the size rules.
+func withSizes(ctx context.Context, sizes map[string]float64) context.Context {
+	if len(sizes) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, sizesKey{}, sizes)
+}
+
+// size returns the size reported by the Sizer the field under validation was given as, if any.
+func (ctx *ValidationContext) size() (float64, bool) {
+	sizes, _ := ctx.Context().Value(sizesKey{}).(map[string]float64)
+	size, ok := sizes[ctx.FieldName]
+	return size, ok
+}
+
+// UnwrapValues makes validators parsed afterwards validate the value that driver.Valuer and
+// json.Marshaler values given to ValidateData, ValidateStruct or ValidateItems stand for, e.g. the
+// string of a custom ORM column type or the JSON of an option type, so model structs can be validated
+// directly. An error returned by Value or MarshalJSON fails the validation. The sql.Null types are
+// always unwrapped.
+func (f *Factory) UnwrapValues() {
+	f.unwrap = true
+}
+
+// flattened is decoded data converted to the string input of the validator, with the type of each key
+// and the size of the Sizer values. Maps and slices only get a type, their elements get the values.
+type flattened struct {
+	value  map[string]string
+	types  map[string]string
+	sizes  map[string]float64
+	unwrap bool   // see Factory.UnwrapValues
+	limits Limits // checked while the data is traversed, see Limits.checkKey
+	err    error  // first error unwrapping a value or exceeding a limit
+
+	visiting map[reference]struct{} // pointers, maps and slices being traversed, see enter
+}
+
+// reference identifies a pointer, map or slice by the memory it refers to. The length tells a slice
+// from the shorter ones sharing its backing array.
+type reference struct {
+	pointer uintptr
+	typ     reflect.Type
+	length  int
+}
+
+func (v *Validator) newFlattened() *flattened {
+	return &flattened{
+		value:  make(map[string]string),
+		types:  make(map[string]string),
+		sizes:  make(map[string]float64),
+		unwrap: v.unwrap,
+		limits: v.limits,
+	}
+}
+
+// validate runs v on the flattened data, unless unwrapping a value failed.
+func (f *flattened) validate(ctx context.Context, v *Validator) error {
+	if f.err != nil {
+		return f.err
+	}
+	_, err := v.run(withSizes(ctx, f.sizes), f.value, f.types, nil)
+	return err
+}
+
+// validateAll is like validate but collects the errors of every field, as ValidateAll does.
+func (f *flattened) validateAll(ctx context.Context, v *Validator) (*ErrorBag, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	bag := &ErrorBag{}
+	if _, err := v.run(withSizes(ctx, f.sizes), f.value, f.types, bag); err != nil && err != error(bag) {
+		return nil, err
+	}
+	return bag, nil
+}
+
+// validateDataAll is like ValidateDataContext but collects the errors of every field.
+func (v *Validator) validateDataAll(ctx context.Context, data map[string]interface{}) (*ErrorBag, error) {
+	flat := v.newFlattened()
+	for key, item := range data {
+		flat.add(key, item)
+	}
+	return flat.validateAll(ctx, v)
+}
+
+// add flattens item under key. Nil pointers are null, and an OptionalValue that is nil or not set
+// leaves key out. time.Time values are formatted as RFC 3339 for the date rules, and the sql.Null types are null
+// when not valid and their value otherwise. The traversal stops at the first error, such as an
+// exceeded limit.
+func (f *flattened) add(key string, item interface{}) {
+	if f.err != nil {
+		return
+	}
+	if err := f.limits.checkKey(key, len(f.value)); err != nil {
+		f.fail(err)
+		return
+	}
+	reflected := reflect.ValueOf(item)
+	if (reflected.Kind() == reflect.Pointer || reflected.Kind() == reflect.Interface) && reflected.IsNil() {
+		// before the interface assertions below, whose methods may dereference the pointer
+		if _, ok := item.(OptionalValue); !ok {
+			f.types[key], f.value[key] = TypeNull, ""
+		}
+		return
+	}
+	if optional, ok := item.(OptionalValue); ok {
+		if optional.IsSet() {
+			f.add(key, optional.Get())
+		}
+		return
+	}
+	if sizer, ok := item.(Sizer); ok {
+		f.sizes[key] = sizer.Size()
+	}
+	switch typed := item.(type) {
+	case nil:
+		f.types[key], f.value[key] = TypeNull, ""
+		return
+	case string:
+		f.types[key], f.value[key] = TypeString, typed
+		return
+	case json.Number:
+		f.types[key], f.value[key] = TypeNumber, typed.String()
+		return
+	case time.Time:
+		f.types[key], f.value[key] = TypeStringer, typed.Format(time.RFC3339Nano)
+		return
+	case sql.NullString:
+		f.add(key, nullable(typed.String, typed.Valid))
+		return
+	case sql.NullInt64:
+		f.add(key, nullable(typed.Int64, typed.Valid))
+		return
+	case sql.NullInt32:
+		f.add(key, nullable(typed.Int32, typed.Valid))
+		return
+	case sql.NullInt16:
+		f.add(key, nullable(typed.Int16, typed.Valid))
+		return
+	case sql.NullByte:
+		f.add(key, nullable(typed.Byte, typed.Valid))
+		return
+	case sql.NullFloat64:
+		f.add(key, nullable(typed.Float64, typed.Valid))
+		return
+	case sql.NullBool:
+		f.add(key, nullable(typed.Bool, typed.Valid))
+		return
+	case sql.NullTime:
+		f.add(key, nullable(typed.Time, typed.Valid))
+		return
+	}
+	if f.unwrap && f.addUnwrapped(key, item) {
+		return
+	}
+	if stringer, ok := item.(fmt.Stringer); ok {
+		f.types[key], f.value[key] = TypeStringer, stringer.String()
+		return
+	}
+	switch reflected.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		ref, ok := f.enter(key, reflected)
+		if !ok {
+			return
+		}
+		defer delete(f.visiting, ref)
+	}
+	switch reflected.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		f.add(key, reflected.Elem().Interface())
+	case reflect.String:
+		f.types[key], f.value[key] = TypeString, reflected.String()
+	case reflect.Bool:
+		f.types[key], f.value[key] = TypeBoolean, strconv.FormatBool(reflected.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f.types[key], f.value[key] = TypeNumber, strconv.FormatInt(reflected.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f.types[key], f.value[key] = TypeNumber, strconv.FormatUint(reflected.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		f.types[key], f.value[key] = TypeNumber, strconv.FormatFloat(reflected.Float(), 'f', -1, reflected.Type().Bits())
+	case reflect.Slice, reflect.Array:
+		f.types[key] = TypeArray
+		if err := f.limits.checkItems(key, reflected.Len()); err != nil {
+			f.fail(err)
+			return
+		}
+		for i := 0; i < reflected.Len(); i++ {
+			f.add(key+"."+strconv.Itoa(i), reflected.Index(i).Interface())
+		}
+	case reflect.Map:
+		f.types[key] = TypeMap
+		items := 0
+		iter := reflected.MapRange()
+		for iter.Next() {
+			name := fmt.Sprint(iter.Key().Interface())
+			if isDigits(name) {
+				items++
+				if err := f.limits.checkItems(key, items); err != nil {
+					f.fail(err)
+					return
+				}
+			}
+			f.add(key+"."+name, iter.Value().Interface())
+		}
+	case reflect.Struct:
+		f.types[key] = TypeMap
+		f.addStruct(key+".", reflected)
+	default:
+		f.types[key], f.value[key] = TypeOther, fmt.Sprint(item)
+	}
+}
+
+// addUnwrapped flattens the value a driver.Valuer or json.Marshaler stands for, reporting whether item
+// is one of them.
+func (f *flattened) addUnwrapped(key string, item interface{}) bool {
+	switch typed := item.(type) {
+	case driver.Valuer:
+		value, err := typed.Value()
+		if err != nil {
+			f.fail(fmt.Errorf("field %s: %w", key, err))
+			return true
+		}
+		f.add(key, value)
+		return true
+	case json.Marshaler:
+		encoded, err := typed.MarshalJSON()
+		var value interface{}
+		if err == nil {
+			decoder := json.NewDecoder(bytes.NewReader(encoded))
+			decoder.UseNumber()
+			err = decoder.Decode(&value)
+		}
+		if err != nil {
+			f.fail(fmt.Errorf("field %s: %w", key, err))
+			return true
+		}
+		f.add(key, value)
+		return true
+	}
+	return false
+}
+
+// enter marks the value reflected refers to as being traversed under key. It fails and returns false
+// when the value already is, as flattening a value that contains itself would never end.
+func (f *flattened) enter(key string, reflected reflect.Value) (reference, bool) {
+	ref := reference{pointer: reflected.Pointer(), typ: reflected.Type()}
+	if reflected.Kind() == reflect.Slice {
+		ref.length = reflected.Len()
+	}
+	if _, ok := f.visiting[ref]; ok {
+		f.fail(fmt.Errorf("field %s: cyclic value of type %s", key, ref.typ))
+		return ref, false
+	}
+	if f.visiting == nil {
+		f.visiting = make(map[reference]struct{})
+	}
+	f.visiting[ref] = struct{}{}
+	return ref, true
+}
+
+func (f *flattened) fail(err error) {
+	if f.err == nil {
+		f.err = err
+	}
+}
+
+// addStruct flattens the exported fields of a struct under prefix, see ValidateStruct.
+func (f *flattened) addStruct(prefix string, reflected reflect.Value) {
+	structType := reflected.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" && field.Anonymous {
+			embedded := reflect.Indirect(reflected.Field(i))
+			if embedded.Kind() == reflect.Struct {
+				f.addStruct(prefix, embedded)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		f.add(prefix+name, reflected.Field(i).Interface())
+	}
+}
+
+// nullable returns value when valid and nil otherwise.
+func nullable(value interface{}, valid bool) interface{} {
+	if !valid {
+		return nil
+	}
+	return value
+}
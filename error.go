@@ -17,3 +17,34 @@ type ErrParsingRules struct {
 func (e *ErrParsingRules) Error() string {
 	return fmt.Sprintf("error parsing validation rules: %s", e.Reason)
 }
+
+// ErrLimitExceeded is returned by Validate, before any rule runs, when the input exceeds one of the
+// Limits of the factory. Limit is "max_depth", "max_fields" or "max_array_items". ValidateData,
+// ValidateStruct and ValidateItems stop traversing the input as soon as a limit is exceeded.
+type ErrLimitExceeded struct {
+	Limit string
+	Max   int
+	Field string // offending key or array, truncated to 64 bytes; empty for max_fields
+}
+
+func (e *ErrLimitExceeded) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("input exceeds %s of %d", e.Limit, e.Max)
+	}
+	return fmt.Sprintf("input exceeds %s of %d at %s", e.Limit, e.Max, e.Field)
+}
+
+// RuleError reports a problem with one rule of a field, see Factory.CheckRules.
+type RuleError struct {
+	Field string
+	Rule  string // rule as written, e.g. "regex:/[a-z/"
+	Err   error
+}
+
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("%s: %s: %v", e.Field, e.Rule, e.Err)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,117 @@
+package validation
+
+import "strings"
+
+// ErrorBag holds the error messages of each invalid field, see Validator.ValidateAll. Fields are kept
+// in the order they were validated. It implements error, joining all messages.
+type ErrorBag struct {
+	fields   []string
+	messages map[string][]string
+}
+
+// Add appends a message for field.
+func (b *ErrorBag) Add(field string, message string) {
+	if b.messages == nil {
+		b.messages = make(map[string][]string)
+	}
+	if _, ok := b.messages[field]; !ok {
+		b.fields = append(b.fields, field)
+	}
+	b.messages[field] = append(b.messages[field], message)
+}
+
+// Has reports whether field has any message.
+func (b *ErrorBag) Has(field string) bool {
+	return len(b.messages[field]) > 0
+}
+
+// Get returns the messages of field.
+func (b *ErrorBag) Get(field string) []string {
+	return b.messages[field]
+}
+
+// First returns the first message of field, or "" when it has none. The messages of a field are in
+// the order its rules are declared, so this is the message of the first failing rule.
+func (b *ErrorBag) First(field string) string {
+	if messages := b.messages[field]; len(messages) > 0 {
+		return messages[0]
+	}
+	return ""
+}
+
+// LimitMessages makes ValidateAll keep at most n messages per field, 1 keeping the message of the first
+// failing rule only. The remaining rules of a field are not run once it has n messages. Zero means
+// unlimited.
+func (v *Validator) LimitMessages(n int) *Validator {
+	v.perField = n
+	return v
+}
+
+// DedupeMessages makes ValidateAll drop messages equal to a message already added for the same field,
+// e.g. when overlapping rules share a custom message.
+func (v *Validator) DedupeMessages() *Validator {
+	v.dedupe = true
+	return v
+}
+
+// FirstOfAll returns the first message of the first invalid field in validation order, or "" when the
+// bag is empty, for forms showing a single error.
+func (b *ErrorBag) FirstOfAll() string {
+	if len(b.fields) == 0 {
+		return ""
+	}
+	return b.First(b.fields[0])
+}
+
+// Keys returns the invalid fields in the order they were validated.
+func (b *ErrorBag) Keys() []string {
+	return append([]string(nil), b.fields...)
+}
+
+// All returns the messages of every field.
+func (b *ErrorBag) All() map[string][]string {
+	all := make(map[string][]string, len(b.messages))
+	for field, messages := range b.messages {
+		all[field] = append([]string(nil), messages...)
+	}
+	return all
+}
+
+func (b *ErrorBag) IsEmpty() bool {
+	return len(b.fields) == 0
+}
+
+func (b *ErrorBag) Len() int {
+	return len(b.fields)
+}
+
+// ToStringSlice returns every message, field by field in validation order and in rule order within a
+// field.
+func (b *ErrorBag) ToStringSlice() []string {
+	var messages []string
+	for _, field := range b.fields {
+		messages = append(messages, b.messages[field]...)
+	}
+	return messages
+}
+
+// Join returns every message in the order of ToStringSlice, separated by sep.
+func (b *ErrorBag) Join(sep string) string {
+	return strings.Join(b.ToStringSlice(), sep)
+}
+
+// RenderList returns every message in the order of ToStringSlice as a plain-text list, one "- message"
+// line each, for CLI tools and plain-text emails. It returns "" for an empty bag.
+func (b *ErrorBag) RenderList() string {
+	var sb strings.Builder
+	for _, message := range b.ToStringSlice() {
+		sb.WriteString("- ")
+		sb.WriteString(message)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func (b *ErrorBag) Error() string {
+	return b.Join("\n")
+}
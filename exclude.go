@@ -0,0 +1,73 @@
+package validation
+
+import "fmt"
+
+// Exclude rules remove the field, and the keys nested under it, from the output of Validated and skip
+// its remaining rules. They never fail.
+
+// exclude
+func Exclude(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+	return excludeWhen(func(ctx *ValidationContext) bool { return true }), nil
+}
+
+// exclude_if:anotherfield,value,...
+// The field is excluded when another field equals one of the values.
+func ExcludeIf(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("exclude_if rule requires at least 2 arguments")
+	}
+	other, values := args[0], args[1:]
+	return excludeWhen(func(ctx *ValidationContext) bool { return fieldEquals(ctx, other, values) }), nil
+}
+
+// exclude_unless:anotherfield,value,...
+// The field is excluded unless another field equals one of the values.
+func ExcludeUnless(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("exclude_unless rule requires at least 2 arguments")
+	}
+	other, values := args[0], args[1:]
+	return excludeWhen(func(ctx *ValidationContext) bool { return !fieldEquals(ctx, other, values) }), nil
+}
+
+// ExcludeWhen returns a rule excluding the field when condition holds for the input, like Laravel's
+// Rule::excludeIf(fn). Register it under a name, as RequiredWhen.
+func ExcludeWhen(condition func(data map[string]interface{}) bool) RuleConstructor {
+	return func(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+		return excludeWhen(func(ctx *ValidationContext) bool { return condition(ctx.data()) }), nil
+	}
+}
+
+// ExcludeUnlessWhen returns a rule excluding the field unless condition holds for the input, like
+// Laravel's Rule::excludeUnless(fn).
+func ExcludeUnlessWhen(condition func(data map[string]interface{}) bool) RuleConstructor {
+	return func(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+		return excludeWhen(func(ctx *ValidationContext) bool { return !condition(ctx.data()) }), nil
+	}
+}
+
+func excludeWhen(condition func(ctx *ValidationContext) bool) ValidationRule {
+	return func(ctx *ValidationContext) (bool, error) {
+		if condition(ctx) {
+			ctx.excluded = true
+			return false, nil
+		}
+		return true, nil
+	}
+}
+
+// withoutExcluded returns a copy of value without the excluded fields and the keys nested under them.
+func withoutExcluded(value map[string]string, excluded []string) map[string]string {
+	kept := make(map[string]string, len(value))
+	for key, item := range value {
+		kept[key] = item
+	}
+	for _, field := range excluded {
+		for key := range kept {
+			if key == field || (len(key) > len(field) && key[len(field)] == '.' && key[:len(field)] == field) {
+				delete(kept, key)
+			}
+		}
+	}
+	return kept
+}
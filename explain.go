@@ -0,0 +1,72 @@
+package validation
+
+import "context"
+
+// AttributeTrace is the trace of the validation of one field, see Validator.Explain.
+type AttributeTrace struct {
+	Attribute string `json:"attribute"`
+	// Value is the value of the field, Redacted for redacted and sensitive fields.
+	Value   string      `json:"value"`
+	Present bool        `json:"present"`
+	Rules   []RuleTrace `json:"rules"`
+}
+
+// RuleTrace is the trace of one rule run.
+type RuleTrace struct {
+	Rule string   `json:"rule"`
+	Args []string `json:"args,omitempty"`
+	// Others holds the values of the other fields the rule looked up, by their resolved key, e.g.
+	// "items.3.min" for "gte:items.*.min".
+	Others  map[string]string `json:"others,omitempty"`
+	Passed  bool              `json:"passed"`
+	Message string            `json:"message,omitempty"`
+}
+
+type explainKey struct{}
+
+// Explain validates value like ValidateAll and returns a trace of every field validated, in validation
+// order, with the rules run on it, their arguments, the other fields they looked up and their result.
+// Rules skipped for a nullable field or after a stopping failure are absent from the trace. The error
+// is only set when the input is rejected as a whole, as for ValidateAll.
+func (v *Validator) Explain(value map[string]string) ([]AttributeTrace, error) {
+	return v.ExplainContext(context.Background(), value)
+}
+
+// ExplainContext is like Explain but carries ctx to the rules, as ValidateContext does.
+func (v *Validator) ExplainContext(ctx context.Context, value map[string]string) ([]AttributeTrace, error) {
+	var traces []*AttributeTrace
+	ctx = context.WithValue(ctx, explainKey{}, &traces)
+	if _, err := v.run(ctx, value, nil, &ErrorBag{}); err != nil {
+		if _, ok := err.(*ErrorBag); !ok {
+			return nil, err
+		}
+	}
+	explanation := make([]AttributeTrace, len(traces))
+	for i, trace := range traces {
+		explanation[i] = *trace
+	}
+	return explanation, nil
+}
+
+// traceAttribute starts the trace of field when ctx comes from Explain, and returns nil otherwise.
+func (v *Validator) traceAttribute(ctx context.Context, field string, value map[string]string) *AttributeTrace {
+	traces, ok := ctx.Value(explainKey{}).(*[]*AttributeTrace)
+	if !ok {
+		return nil
+	}
+	_, present := value[field]
+	trace := &AttributeTrace{Attribute: field, Value: v.redactedValue(field, value), Present: present}
+	*traces = append(*traces, trace)
+	return trace
+}
+
+func (t *AttributeTrace) addRule(rule string, args []string, others map[string]string, err error) {
+	ruleTrace := RuleTrace{Rule: rule, Args: args, Passed: err == nil}
+	if len(others) > 0 {
+		ruleTrace.Others = others
+	}
+	if err != nil {
+		ruleTrace.Message = err.Error()
+	}
+	t.Rules = append(t.Rules, ruleTrace)
+}
@@ -1,8 +1,10 @@
 package validation
 
 import (
+	"fmt"
 	"maps"
 	"slices"
+	"sort"
 	"strings"
 )
 
@@ -10,6 +12,21 @@ type Factory struct {
 	rules        map[string]RuleConstructor
 	config       map[string]interface{}
 	numericRules []string
+	metrics      MetricsSink
+	tracer       Tracer
+	tracedRules  []string
+	onFailure    FailureHook
+	onAttribute  AttributeHook
+	onRule       RuleHook
+	redact       []string
+	sensitive    []string
+	limits       Limits
+	sanitizers   map[string]Sanitizer
+	normalize    func(string) string
+	customRules  []string // names given to RegisterRule
+	ruleSets     map[string]string
+	unwrap       bool
+	trimNumbers  bool
 }
 
 func NewFactory() *Factory {
@@ -19,6 +36,15 @@ func NewFactory() *Factory {
 		embeddedUtilitiesRules,
 		embeddedNumberRules,
 		embeddedSizeRules,
+		embeddedArrayRules,
+		embeddedColorRules,
+		embeddedNetworkRules,
+		embeddedFormatRules,
+		embeddedDateRules,
+		embeddedLocaleRules,
+		embeddedGeoRules,
+		embeddedPathRules,
+		embeddedDatabaseRules,
 		// Add other embedded rule maps here as needed
 	}
 
@@ -33,11 +59,17 @@ func NewFactory() *Factory {
 		rules:        embeddedRulesCopy,
 		config:       make(map[string]interface{}),
 		numericRules: numericRules,
+		tracedRules:  slices.Clone(defaultTracedRules),
+		sanitizers:   maps.Clone(embeddedSanitizers),
+		ruleSets:     make(map[string]string),
 	}
 }
 
 func (f *Factory) RegisterRule(name string, constructor RuleConstructor) {
 	f.rules[name] = constructor
+	if !slices.Contains(f.customRules, name) {
+		f.customRules = append(f.customRules, name)
+	}
 }
 
 func (f *Factory) SetConfig(key string, value interface{}) {
@@ -51,9 +83,14 @@ func (f *Factory) UnsetConfig(key string) {
 func (f *Factory) Parse(structRules map[string]string) (*Validator, error) {
 	parsedRules := make(map[string]ParseResult, len(structRules))
 	for field, ruleStr := range structRules {
-		ruleStrs := strings.Split(ruleStr, "|")
+		ruleStrs, err := f.expandRuleSets(strings.Split(ruleStr, "|"), nil)
+		if err != nil {
+			return nil, err
+		}
 		rules := make([]ValidationRule, 0, len(ruleStrs))
 		ruleNames := make([]string, 0, len(ruleStrs))
+		ruleArgs := make([][]string, 0, len(ruleStrs))
+		var sanitizers []Sanitizer
 		hasNumeric := false
 		for _, r := range ruleStrs {
 			parts := strings.SplitN(r, ":", 2)
@@ -65,11 +102,23 @@ func (f *Factory) Parse(structRules map[string]string) (*Validator, error) {
 			if !hasNumeric && slices.Contains(f.numericRules, ruleName) {
 				hasNumeric = true
 			}
-			ruleNames = append(ruleNames, ruleName)
 			var args []string
 			if len(parts) > 1 {
 				args = strings.Split(parts[1], ",")
 			}
+			if ruleName == "sanitize" {
+				if len(args) < 1 {
+					return nil, fmt.Errorf("sanitize rule requires at least 1 argument")
+				}
+				for _, name := range args {
+					sanitizer, ok := f.sanitizers[strings.TrimSpace(name)]
+					if !ok {
+						return nil, fmt.Errorf("sanitize rule has unknown sanitizer %q", name)
+					}
+					sanitizers = append(sanitizers, sanitizer)
+				}
+				continue
+			}
 			constructor, exists := f.rules[ruleName]
 			if !exists {
 				return nil, &ErrUnknownRule{Rule: ruleName}
@@ -79,9 +128,39 @@ func (f *Factory) Parse(structRules map[string]string) (*Validator, error) {
 				return nil, err
 			}
 			rules = append(rules, rule)
+			ruleNames = append(ruleNames, ruleName)
+			ruleArgs = append(ruleArgs, args)
 		}
 
-		parsedRules[field] = ParseResult{Rules: rules, RuleNames: ruleNames, HasNumericRule: hasNumeric}
+		parsedRules[field] = ParseResult{
+			Rules:          rules,
+			RuleNames:      ruleNames,
+			RuleArgs:       ruleArgs,
+			HasNumericRule: hasNumeric,
+			Nullable:       slices.Contains(ruleNames, "nullable"),
+			Sanitizers:     sanitizers,
+		}
+	}
+	fields := make([]string, 0, len(parsedRules))
+	for field := range parsedRules {
+		fields = append(fields, field)
 	}
-	return &Validator{rules: parsedRules}, nil
+	sort.Strings(fields)
+	return &Validator{
+		rules:       parsedRules,
+		fields:      fields,
+		metrics:     f.metrics,
+		tracer:      f.tracer,
+		traced:      slices.Clone(f.tracedRules),
+		onFailure:   f.onFailure,
+		onAttribute: f.onAttribute,
+		onRule:      f.onRule,
+		redact:      slices.Clone(f.redact),
+		sensitive:   slices.Clone(f.sensitive),
+		limits:      f.limits,
+		normalize:   f.normalize,
+		inputLength: defaultInputLength,
+		unwrap:      f.unwrap,
+		trimNumbers: f.trimNumbers,
+	}, nil
 }
@@ -0,0 +1,112 @@
+package validation
+
+import (
+	"maps"
+	"regexp"
+	"sync"
+)
+
+// FormatValidator reports whether a value is in a named format, see FormatRegistry.
+type FormatValidator func(value string) bool
+
+// FormatRegistry holds the named formats checked by the format rule, such as national identifiers
+// with their checksums, so applications register the ones they need instead of the library
+// hard-coding every country. It is set with Factory.SetFormatRegistry (config key "format_registry")
+// and is safe for concurrent use, so one registry can be shared by several factories.
+type FormatRegistry struct {
+	mu      sync.RWMutex
+	formats map[string]FormatValidator
+}
+
+// NewFormatRegistry returns a registry holding the built-in formats, ssn_us and cpf_br.
+func NewFormatRegistry() *FormatRegistry {
+	return &FormatRegistry{formats: maps.Clone(embeddedFormats)}
+}
+
+// Register adds a format, replacing the one of the same name. Validators parsed before keep the
+// format they were parsed with.
+func (r *FormatRegistry) Register(name string, format FormatValidator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.formats[name] = format
+}
+
+// Lookup returns the format registered under name.
+func (r *FormatRegistry) Lookup(name string) (FormatValidator, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	format, ok := r.formats[name]
+	return format, ok
+}
+
+// SetFormatRegistry sets the registry of the format rule, NewFormatRegistry() by default.
+func (f *Factory) SetFormatRegistry(registry *FormatRegistry) {
+	f.SetConfig("format_registry", registry)
+}
+
+// RegisterFormat adds a format to the registry of the factory, creating it from NewFormatRegistry
+// when none is set: factory.RegisterFormat("nif_pt", isNIF) makes "format:nif_pt" available to the
+// validators parsed afterwards.
+func (f *Factory) RegisterFormat(name string, format FormatValidator) {
+	registry, ok := f.config["format_registry"].(*FormatRegistry)
+	if !ok {
+		registry = NewFormatRegistry()
+		f.SetFormatRegistry(registry)
+	}
+	registry.Register(name, format)
+}
+
+// defaultFormatRegistry is used by the format rule when the factory has no registry.
+var defaultFormatRegistry = NewFormatRegistry()
+
+var embeddedFormats = map[string]FormatValidator{
+	"ssn_us": isUSSocialSecurityNumber,
+	"cpf_br": isBrazilianCPF,
+}
+
+var ssnRegexp = regexp.MustCompile(`^(\d{3})-?(\d{2})-?(\d{4})$`)
+
+// isUSSocialSecurityNumber reports whether value is a US Social Security number, "123-45-6789" or
+// "123456789", in a range the SSA may assign: the area is not 000, 666 or 900-999, the group not 00
+// and the serial not 0000.
+func isUSSocialSecurityNumber(value string) bool {
+	parts := ssnRegexp.FindStringSubmatch(value)
+	if parts == nil {
+		return false
+	}
+	area, group, serial := parts[1], parts[2], parts[3]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+var cpfRegexp = regexp.MustCompile(`^\d{3}\.?\d{3}\.?\d{3}-?\d{2}$`)
+
+// isBrazilianCPF reports whether value is a Brazilian CPF, "123.456.789-09" or "12345678909", with
+// valid check digits. Numbers of a single repeated digit pass the checksum but are not issued.
+func isBrazilianCPF(value string) bool {
+	if !cpfRegexp.MatchString(value) {
+		return false
+	}
+	digits := make([]int, 0, 11)
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, int(r-'0'))
+		}
+	}
+	repeated := true
+	for _, d := range digits[1:] {
+		repeated = repeated && d == digits[0]
+	}
+	if repeated {
+		return false
+	}
+	for check := 9; check <= 10; check++ {
+		sum := 0
+		for i := 0; i < check; i++ {
+			sum += digits[i] * (check + 1 - i)
+		}
+		if digit := sum * 10 % 11 % 10; digit != digits[check] {
+			return false
+		}
+	}
+	return true
+}
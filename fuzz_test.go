@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+// The fuzz targets only check that parsing and validating never panic; run one with e.g.
+// go test -run '^$' -fuzz FuzzParseRules -fuzztime 30s
+
+func FuzzParseRules(f *testing.F) {
+	for _, seed := range []string{
+		"required|email",
+		"nullable|integer|min:3|max:10",
+		"regex:/^[a-z]+$/i",
+		"in:a,b,c|not_in:",
+		"url:https,max=2048,no_credentials",
+		"json:object,max_depth=3",
+		"|:|::,|",
+		"required_if:items.*.type,a,b",
+	} {
+		f.Add(seed, "value")
+	}
+	factory := NewFactory()
+	f.Fuzz(func(t *testing.T, rules string, value string) {
+		// rules with side effects outside the input
+		if strings.Contains(rules, "exists") || strings.Contains(rules, "unique") {
+			return
+		}
+		validator, err := factory.Parse(map[string]string{"field": rules, "other": "nullable"})
+		if err != nil {
+			return
+		}
+		validator.ValidateAll(map[string]string{"field": value, "other": value})
+	})
+}
+
+func FuzzRegexRule(f *testing.F) {
+	for _, seed := range []string{"/^foo$/i", "/a/b/ms", "^[0-9]+$", "/", "//", "/(/", "/x/iu"} {
+		f.Add(seed, "foo")
+	}
+	f.Fuzz(func(t *testing.T, pattern string, value string) {
+		for _, constructor := range []RuleConstructor{constructRegex, constructNotRegex} {
+			rule, err := constructor(nil, pattern)
+			if err != nil {
+				continue
+			}
+			rule(&ValidationContext{FieldName: "field", FieldValue: value})
+		}
+	})
+}
+
+func FuzzDateRules(f *testing.F) {
+	for _, seed := range []string{"1h30m", "-5s", "*/15 * * * *", "0 0 1-31/2 * mon-fri", "@every 1m", "@daily", "? ? ? ? ?", "1-", "2024-01-31T10:00:00+09:00", "2024-02-30"} {
+		f.Add(seed)
+	}
+	rules := []ValidationRule{}
+	for _, c := range []struct {
+		constructor RuleConstructor
+		args        []string
+	}{
+		{constructDate, nil},
+		{constructDate, []string{"iso8601"}},
+		{constructDuration, []string{"min=1s", "max=1h"}},
+		{constructCron, nil},
+		{constructCron, []string{"optional_seconds"}},
+	} {
+		rule, err := c.constructor(nil, c.args...)
+		if err != nil {
+			f.Fatalf("Failed to construct rule: %v", err)
+		}
+		rules = append(rules, rule)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		for _, rule := range rules {
+			rule(&ValidationContext{FieldName: "field", FieldValue: value})
+		}
+	})
+}
+
+func FuzzURLAndEmail(f *testing.F) {
+	for _, seed := range []string{"https://example.com/a?b=c", "http://user:pass@[::1]:80/", "mailto:a@b.c", "ann@example.com", "xn--ls8h.la", "http://%zz", "a@b"} {
+		f.Add(seed)
+	}
+	rules := []ValidationRule{}
+	for _, c := range []struct {
+		constructor RuleConstructor
+		args        []string
+	}{
+		{constructURL, nil},
+		{constructURL, []string{"https", "no_credentials", "max=64"}},
+		{constructEmail, nil},
+		{constructHostname, nil},
+	} {
+		rule, err := c.constructor(nil, c.args...)
+		if err != nil {
+			f.Fatalf("Failed to construct rule: %v", err)
+		}
+		rules = append(rules, rule)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		for _, rule := range rules {
+			rule(&ValidationContext{FieldName: "field", FieldValue: value})
+		}
+	})
+}
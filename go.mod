@@ -2,4 +2,8 @@ module github.com/shugen002/validation
 
 go 1.21
 
-require github.com/google/uuid v1.3.0
+require (
+	github.com/google/uuid v1.3.0
+	golang.org/x/net v0.30.0
+	golang.org/x/text v0.19.0
+)
@@ -0,0 +1,77 @@
+// Package gormverifier implements validation.PresenceVerifier over a *gorm.DB for the exists and
+// unique rules. It lives in its own module so the core package does not depend on GORM.
+package gormverifier
+
+import (
+	"context"
+
+	"github.com/shugen002/validation"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Verifier struct {
+	db     *gorm.DB
+	models map[string]interface{}
+	scopes map[string][]func(*gorm.DB) *gorm.DB
+}
+
+var (
+	_ validation.PresenceVerifier        = (*Verifier)(nil)
+	_ validation.ContextPresenceVerifier = (*Verifier)(nil)
+)
+
+func New(db *gorm.DB) *Verifier {
+	return &Verifier{
+		db:     db,
+		models: make(map[string]interface{}),
+		scopes: make(map[string][]func(*gorm.DB) *gorm.DB),
+	}
+}
+
+// Model makes queries on table go through model, e.g. Model("users", &User{}). Models with a
+// gorm.DeletedAt field then ignore soft-deleted records, like any GORM query on them.
+// Configure the verifier before handing it to the factory; it is not safe to change concurrently.
+func (v *Verifier) Model(table string, model interface{}) *Verifier {
+	v.models[table] = model
+	return v
+}
+
+// Scope adds where closures applied to every query on table, e.g. to restrict a rule to a tenant.
+func (v *Verifier) Scope(table string, scopes ...func(*gorm.DB) *gorm.DB) *Verifier {
+	v.scopes[table] = append(v.scopes[table], scopes...)
+	return v
+}
+
+func (v *Verifier) Count(table string, column string, value string, where map[string]string) (int, error) {
+	return v.CountContext(context.Background(), table, column, value, where)
+}
+
+// CountContext runs the query with ctx, so it is cancelled with the validation and traced with it.
+func (v *Verifier) CountContext(ctx context.Context, table string, column string, value string, where map[string]string) (int, error) {
+	query := v.db.Session(&gorm.Session{NewDB: true, Context: ctx})
+	if model, ok := v.models[table]; ok {
+		query = query.Model(model)
+	} else {
+		query = query.Table(table)
+	}
+	query = query.Scopes(v.scopes[table]...).Where(clause.Eq{Column: clause.Column{Name: column}, Value: value})
+	for whereColumn, expected := range where {
+		col := clause.Column{Name: whereColumn}
+		switch {
+		case expected == "NULL":
+			query = query.Where(clause.Eq{Column: col, Value: nil})
+		case expected == "NOT_NULL":
+			query = query.Where(clause.Neq{Column: col, Value: nil})
+		case len(expected) > 0 && expected[0] == '!':
+			query = query.Where(clause.Neq{Column: col, Value: expected[1:]})
+		default:
+			query = query.Where(clause.Eq{Column: col, Value: expected})
+		}
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
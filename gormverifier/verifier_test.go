@@ -0,0 +1,61 @@
+package gormverifier
+
+import (
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shugen002/validation"
+	"gorm.io/gorm"
+)
+
+type user struct {
+	ID        uint
+	Email     string
+	TenantID  uint
+	DeletedAt gorm.DeletedAt
+}
+
+func TestVerifier(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&user{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	db.Create(&[]user{{Email: "ann@example.com", TenantID: 1}, {Email: "bob@example.com", TenantID: 2}, {Email: "eve@example.com", TenantID: 1}})
+	db.Delete(&user{}, "email = ?", "eve@example.com")
+	db.Exec("CREATE VIEW tenant_users AS SELECT * FROM users")
+
+	verifier := New(db).
+		Model("users", &user{}).
+		Scope("tenant_users", func(db *gorm.DB) *gorm.DB { return db.Where("tenant_id = ?", 1) })
+	factory := validation.NewFactory()
+	factory.SetPresenceVerifier(verifier)
+
+	tests := []struct {
+		rule  string
+		value string
+		valid bool
+	}{
+		{"exists:users,email", "ann@example.com", true},
+		{"exists:users,email", "eve@example.com", false},        // soft-deleted
+		{"unique:users,email", "eve@example.com", true},         // soft-deleted
+		{"unique:users,email,1", "ann@example.com", true},       // ignores own record
+		{"unique:users,email,2", "ann@example.com", false},      // taken by user 1
+		{"exists:tenant_users,email", "bob@example.com", false}, // outside the scope
+		{"exists:tenant_users,email,deleted_at,NOT_NULL", "eve@example.com", true},
+	}
+	for _, test := range tests {
+		t.Run(test.rule+"/"+test.value, func(t *testing.T) {
+			validator, err := factory.Parse(map[string]string{"email": test.rule})
+			if err != nil {
+				t.Fatalf("Failed to parse rules: %v", err)
+			}
+			err = validator.Validate(map[string]string{"email": test.value})
+			if (err == nil) != test.valid {
+				t.Errorf("Validation result mismatch. Expected valid: %v, got error: %v", test.valid, err)
+			}
+		})
+	}
+}
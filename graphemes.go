@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// graphemeCount returns the number of user-perceived characters of str. It follows the extended
+// grapheme cluster rules of UAX #29 for the cases that matter to length limits: CR LF, combining marks,
+// variation selectors, emoji modifiers and ZWJ sequences, flags made of regional indicator pairs or tag
+// sequences, and Hangul jamo. Invalid UTF-8 bytes count as one character each.
+func graphemeCount(str string) int {
+	count := 0
+	var previous rune = -1
+	regionalIndicators := 0
+	for len(str) > 0 {
+		r, size := utf8.DecodeRuneInString(str)
+		str = str[size:]
+		if previous >= 0 && joinsGrapheme(previous, r, regionalIndicators) {
+			if isRegionalIndicator(r) {
+				regionalIndicators++
+			}
+			previous = r
+			continue
+		}
+		count++
+		regionalIndicators = 0
+		if isRegionalIndicator(r) {
+			regionalIndicators = 1
+		}
+		previous = r
+	}
+	return count
+}
+
+// joinsGrapheme reports whether r continues the cluster ending with previous, regionalIndicators being
+// the number of regional indicators ending the cluster.
+func joinsGrapheme(previous rune, r rune, regionalIndicators int) bool {
+	switch {
+	case previous == '\r':
+		return r == '\n'
+	case previous == '\n' || unicode.IsControl(previous):
+		return false
+	case r == '\u200d' || isGraphemeExtend(r):
+		return true
+	case previous == '\u200d':
+		return isPictographic(r)
+	case isRegionalIndicator(r):
+		return regionalIndicators%2 == 1
+	}
+	return joinsHangul(previous, r)
+}
+
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		unicode.Is(unicode.Variation_Selector, r) ||
+		(r >= 0x1f3fb && r <= 0x1f3ff) || // emoji skin tone modifiers
+		(r >= 0xe0020 && r <= 0xe007f) // tags of subdivision flags
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// isPictographic approximates Extended_Pictographic with the emoji blocks and the symbols commonly
+// joined in ZWJ sequences.
+func isPictographic(r rune) bool {
+	return (r >= 0x1f000 && r <= 0x1faff) || (r >= 0x2600 && r <= 0x27bf) || (r >= 0x2300 && r <= 0x23ff) ||
+		r == 0x2640 || r == 0x2642 || r == 0x2695 || r == 0x2696 || r == 0x2708 || r == 0x2764
+}
+
+// joinsHangul implements the Hangul syllable rules: L (L | V | LV | LVT), (LV | V) (V | T), (LVT | T) T.
+func joinsHangul(previous rune, r rune) bool {
+	switch hangulType(previous) {
+	case 'L':
+		return hangulType(r) != 0
+	case 'V', 'v': // V or LV
+		t := hangulType(r)
+		return t == 'V' || t == 'T'
+	case 'T', 't': // T or LVT
+		return hangulType(r) == 'T'
+	}
+	return false
+}
+
+// hangulType returns 'L', 'V' or 'T' for conjoining jamo, 'v' for LV syllables, 't' for LVT syllables
+// and 0 otherwise.
+func hangulType(r rune) byte {
+	switch {
+	case (r >= 0x1100 && r <= 0x115f) || (r >= 0xa960 && r <= 0xa97c):
+		return 'L'
+	case (r >= 0x1160 && r <= 0x11a7) || (r >= 0xd7b0 && r <= 0xd7c6):
+		return 'V'
+	case (r >= 0x11a8 && r <= 0x11ff) || (r >= 0xd7cb && r <= 0xd7fb):
+		return 'T'
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return 'v'
+		}
+		return 't'
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Redacted replaces the value of redacted and sensitive fields in failure hooks and messages.
+const Redacted = "[REDACTED]"
+
+// FailureHook is called for each failed rule with the context given to ValidateContext, the field,
+// the rule name, the field value (Redacted for redacted fields, empty when absent) and the message.
+type FailureHook func(ctx context.Context, attribute string, rule string, value string, message string)
+
+// Sensitive marks fields whose values must never be echoed, e.g. Sensitive("password", "*.card_number").
+// Their values are replaced with Redacted in the :input message placeholder and in failure hooks.
+// A "*" in a pattern matches any run of characters.
+func (f *Factory) Sensitive(patterns ...string) {
+	f.sensitive = append(f.sensitive, patterns...)
+}
+
+// OnFailure sets a hook to log or audit validation failures of validators parsed afterwards.
+// Values of fields matching one of the redact patterns are replaced with Redacted; a "*" in a
+// pattern matches any run of characters, e.g. "password", "*.token" or "*secret*".
+func (f *Factory) OnFailure(hook FailureHook, redact ...string) {
+	f.onFailure = hook
+	f.redact = redact
+}
+
+// AttributeHook is called before the rules of each field run, with the context given to ValidateContext
+// and the field. Returning false skips the field, its rules are not run.
+type AttributeHook func(ctx context.Context, attribute string) bool
+
+// RuleHook is called after each rule run with the field, the rule name, whether it passed and how long
+// it took.
+type RuleHook func(ctx context.Context, attribute string, rule string, passed bool, duration time.Duration)
+
+// OnAttribute sets a hook called when validators parsed afterwards start validating a field, e.g. for
+// debugging tools or to skip fields selectively.
+func (f *Factory) OnAttribute(hook AttributeHook) {
+	f.onAttribute = hook
+}
+
+// OnRule sets a hook called after each rule run of validators parsed afterwards, e.g. for timing
+// dashboards. Unlike OnFailure it also sees passing rules, and values are never passed to it.
+func (f *Factory) OnRule(hook RuleHook) {
+	f.onRule = hook
+}
+
+func (v *Validator) redactedValue(field string, value map[string]string) string {
+	for _, pattern := range v.redact {
+		if globMatch(pattern, field) {
+			return Redacted
+		}
+	}
+	if v.isSensitive(field) {
+		return Redacted
+	}
+	return value[field]
+}
+
+func (v *Validator) isSensitive(field string) bool {
+	for _, pattern := range v.sensitive {
+		if globMatch(pattern, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultInputLength is the number of characters :input is truncated to, see Validator.TruncateInput.
+const defaultInputLength = 50
+
+// TruncateInput sets the number of characters the :input placeholder is truncated to, an ellipsis
+// marking the cut. It is 50 by default; zero renders whole values.
+func (v *Validator) TruncateInput(n int) *Validator {
+	v.inputLength = n
+	return v
+}
+
+// Input returns the value under validation for use in messages, or Redacted for sensitive fields.
+// It is safe to embed in reports: control characters such as line breaks are replaced with spaces,
+// long values are truncated (see Validator.TruncateInput), and values given to ValidateData as null,
+// arrays or maps render as "null", "array" and "map".
+func (ctx *ValidationContext) Input() string {
+	length := defaultInputLength
+	if ctx.validator != nil {
+		if ctx.validator.isSensitive(ctx.FieldName) {
+			return Redacted
+		}
+		length = ctx.validator.inputLength
+	}
+	switch ctx.Type {
+	case TypeNull, TypeArray, TypeMap:
+		return ctx.Type
+	}
+	input := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, ctx.FieldValue)
+	if length > 0 && utf8.RuneCountInString(input) > length {
+		input = string([]rune(input)[:length]) + "…"
+	}
+	return input
+}
@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits bound the size of the input accepted by Validate, to reject hostile payloads cheaply.
+// Zero means unlimited.
+type Limits struct {
+	// MaxDepth is the maximum number of dot separated segments of a key ("a.b.c" has depth 3).
+	MaxDepth int
+	// MaxFields is the maximum number of keys.
+	MaxFields int
+	// MaxArrayItems is the maximum number of distinct numeric indexes under one key prefix,
+	// e.g. "items.0.name" and "items.1.name" are 2 items of "items".
+	MaxArrayItems int
+}
+
+// SetLimits sets the input limits of validators parsed afterwards.
+func (f *Factory) SetLimits(limits Limits) {
+	f.limits = limits
+}
+
+// maxLimitFieldLength bounds ErrLimitExceeded.Field, which holds a key of hostile input.
+const maxLimitFieldLength = 64
+
+// limitExceeded returns the error of an exceeded limit, with field truncated to maxLimitFieldLength.
+func (l Limits) limitExceeded(limit string, max int, field string) error {
+	if len(field) > maxLimitFieldLength {
+		cut := maxLimitFieldLength
+		for cut > 0 && !utf8.RuneStart(field[cut]) {
+			cut--
+		}
+		field = field[:cut] + "..."
+	}
+	return &ErrLimitExceeded{Limit: limit, Max: max, Field: field}
+}
+
+// checkKey reports an error when key, added to the flattened input of ValidateData, exceeds MaxDepth,
+// or when the fields already flattened exceed MaxFields, so deep or large input is rejected while it
+// is traversed instead of after.
+func (l Limits) checkKey(key string, fields int) error {
+	if l.MaxFields > 0 && fields > l.MaxFields {
+		return &ErrLimitExceeded{Limit: "max_fields", Max: l.MaxFields}
+	}
+	if l.MaxDepth > 0 && strings.Count(key, ".")+1 > l.MaxDepth {
+		return l.limitExceeded("max_depth", l.MaxDepth, key)
+	}
+	return nil
+}
+
+// checkItems reports an error when the slice or map under key has more than MaxArrayItems numeric
+// indexes.
+func (l Limits) checkItems(key string, items int) error {
+	if l.MaxArrayItems > 0 && items > l.MaxArrayItems {
+		return l.limitExceeded("max_array_items", l.MaxArrayItems, key)
+	}
+	return nil
+}
+
+func (l Limits) check(value map[string]string) error {
+	if l.MaxFields > 0 && len(value) > l.MaxFields {
+		return &ErrLimitExceeded{Limit: "max_fields", Max: l.MaxFields}
+	}
+	if l.MaxDepth <= 0 && l.MaxArrayItems <= 0 {
+		return nil
+	}
+	items := make(map[string]int)
+	seen := make(map[string]struct{})
+	for key := range value {
+		if l.MaxDepth > 0 && strings.Count(key, ".")+1 > l.MaxDepth {
+			return l.limitExceeded("max_depth", l.MaxDepth, key)
+		}
+		if l.MaxArrayItems <= 0 {
+			continue
+		}
+		for start := 0; start <= len(key); {
+			end := strings.IndexByte(key[start:], '.')
+			if end < 0 {
+				end = len(key)
+			} else {
+				end += start
+			}
+			if item := key[:end]; start > 0 && isDigits(key[start:end]) {
+				if _, ok := seen[item]; !ok {
+					seen[item] = struct{}{}
+					array := key[:start-1]
+					items[array]++
+					if err := l.checkItems(array, items[array]); err != nil {
+						return err
+					}
+				}
+			}
+			start = end + 1
+		}
+	}
+	return nil
+}
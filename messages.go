@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetMessages sets custom error messages keyed by "field.rule", e.g. "email.required" or
+// "items.*.price.min". A "*" in the field part matches any run of characters, so "*.required"
+// applies to every field. When several keys match, the most specific one wins: an exact key first,
+// then the pattern with the most literal characters. Messages may use the :attribute placeholder
+// (display name of the field, see SetAttributeNames) and :input (the value under validation,
+// redacted for fields marked with Factory.Sensitive), plus the parameters of rules documenting
+// them, e.g. :digits for digits or :min and :max for digits_between.
+func (v *Validator) SetMessages(messages map[string]string) *Validator {
+	v.messages = messages
+	return v
+}
+
+// customMessage returns the custom message for a failed rule of the field under validation, if one is configured.
+func (ctx *ValidationContext) customMessage(rule string) (string, bool) {
+	if len(ctx.messages) == 0 {
+		return "", false
+	}
+	message, found := ctx.messages[ctx.FieldName+"."+rule]
+	if !found {
+		bestLiterals := -1
+		bestKey := ""
+		for key, m := range ctx.messages {
+			pattern, ok := strings.CutSuffix(key, "."+rule)
+			if !ok || !strings.Contains(pattern, "*") || !globMatch(pattern, ctx.FieldName) {
+				continue
+			}
+			literals := len(pattern) - strings.Count(pattern, "*")
+			if literals > bestLiterals || (literals == bestLiterals && key < bestKey) {
+				bestLiterals, bestKey, message = literals, key, m
+			}
+		}
+		found = bestLiterals >= 0
+	}
+	if !found {
+		return "", false
+	}
+	return strings.NewReplacer(
+		":attribute", ctx.Attribute(ctx.FieldName),
+		":input", ctx.Input(),
+	).Replace(message), true
+}
+
+// paramError is a rule error carrying "name=value" parameters, which custom messages of the rule
+// can use as ":name" placeholders, e.g. ":digits" for the digits rule.
+type paramError struct {
+	message string
+	params  []string
+	variant string // suffix of the message key tried first, e.g. "array" for "items.min.array"
+}
+
+func (e *paramError) Error() string {
+	return e.message
+}
+
+// errorWithParams formats a rule error like fmt.Errorf and attaches params to it.
+func errorWithParams(params []string, format string, a ...interface{}) error {
+	return &paramError{message: fmt.Sprintf(format, a...), params: params}
+}
+
+// replaceMessageParams replaces the ":name" placeholders of message with the "name=value" params.
+func replaceMessageParams(message string, params []string) string {
+	replacements := make([]string, 0, len(params)*2)
+	for _, param := range params {
+		if name, value, found := strings.Cut(param, "="); found {
+			replacements = append(replacements, ":"+strings.TrimSpace(name), value)
+		}
+	}
+	return strings.NewReplacer(replacements...).Replace(message)
+}
+
+// globMatch reports whether str matches pattern, where "*" matches any run of characters.
+func globMatch(pattern string, str string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == str
+	}
+	if !strings.HasPrefix(str, parts[0]) {
+		return false
+	}
+	str = str[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(str, part)
+		if i < 0 {
+			return false
+		}
+		str = str[i+len(part):]
+	}
+	return strings.HasSuffix(str, last)
+}
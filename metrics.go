@@ -0,0 +1,17 @@
+package validation
+
+import "time"
+
+// MetricsSink receives timings of validations, e.g. to export them to Prometheus (see the
+// promsink module). It is set with Factory.SetMetricsSink and called from concurrent validations.
+type MetricsSink interface {
+	// ObserveValidation is called once per Validate call with the number of rules run.
+	ObserveValidation(rulesCount int, duration time.Duration, failed bool)
+	// ObserveRule is called after each rule run, with the rule name as written in the rules.
+	ObserveRule(rule string, duration time.Duration, failed bool)
+}
+
+// SetMetricsSink sets the sink observing validators parsed afterwards.
+func (f *Factory) SetMetricsSink(sink MetricsSink) {
+	f.metrics = sink
+}
@@ -0,0 +1,45 @@
+package validation
+
+// OptionalValue is implemented by option types telling an absent field from a present zero value.
+// Given to ValidateData, ValidateStruct or ValidateItems, a value that is not set leaves the field out
+// of the input, as if the key were missing, and a set value is validated as the value Get returns. The
+// presence rules (present, missing, filled, required, ...) then see the difference.
+type OptionalValue interface {
+	IsSet() bool
+	Get() interface{}
+}
+
+// Optional is an OptionalValue holding a T, for typed structs where a field may be absent:
+//
+//	type ProfileUpdate struct {
+//		Nickname validation.Optional[string] `json:"nickname"` // "present|max:20" fails when not set
+//	}
+//
+// The zero value is not set; Some returns a set one.
+type Optional[T any] struct {
+	value T
+	set   bool
+}
+
+// Some returns an Optional set to value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, set: true}
+}
+
+// IsSet reports whether the option holds a value.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// Get returns the value of the option, nil when it is not set.
+func (o Optional[T]) Get() interface{} {
+	if !o.set {
+		return nil
+	}
+	return o.value
+}
+
+// Value returns the value of the option and whether it is set.
+func (o Optional[T]) Value() (T, bool) {
+	return o.value, o.set
+}
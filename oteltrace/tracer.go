@@ -0,0 +1,38 @@
+// Package oteltrace adapts an OpenTelemetry tracer to validation.Tracer. It lives in its own
+// module so the core package does not depend on OpenTelemetry.
+package oteltrace
+
+import (
+	"context"
+
+	"github.com/shugen002/validation"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+type span struct {
+	span trace.Span
+}
+
+// New returns a validation.Tracer starting its spans with t, e.g. otel.Tracer("validation").
+// Failed validations and rules record the error and set the span status to Error.
+func New(t trace.Tracer) validation.Tracer {
+	return tracer{tracer: t}
+}
+
+func (t tracer) Start(ctx context.Context, name string) (context.Context, validation.Span) {
+	ctx, s := t.tracer.Start(ctx, name)
+	return ctx, span{span: s}
+}
+
+func (s span) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
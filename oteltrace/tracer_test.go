@@ -0,0 +1,44 @@
+package oteltrace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shugen002/validation"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	factory := validation.NewFactory()
+	factory.SetTracer(New(provider.Tracer("validation")))
+	factory.SetPresenceVerifier(validation.NewInMemoryPresenceVerifier().SeedValues("users", "email", "ann@example.com"))
+	validator, err := factory.Parse(map[string]string{"email": "required|email|unique:users"})
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %v", err)
+	}
+
+	parent, root := provider.Tracer("test").Start(context.Background(), "request")
+	if err := validator.ValidateContext(parent, map[string]string{"email": "ann@example.com"}); err == nil {
+		t.Fatalf("Expected a unique error")
+	}
+	root.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected rule, validation and request spans, got %d", len(spans))
+	}
+	rule, run := spans[0], spans[1]
+	if rule.Name() != "validation.rule.unique" || run.Name() != "validation" {
+		t.Errorf("Unexpected span names: %s, %s", rule.Name(), run.Name())
+	}
+	if rule.Parent().SpanID() != run.SpanContext().SpanID() || run.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Errorf("Spans are not nested under the caller's span")
+	}
+	if run.Status().Code != codes.Error || rule.Status().Code != codes.Error {
+		t.Errorf("Expected error statuses, got %v and %v", run.Status().Code, rule.Status().Code)
+	}
+}
@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"strconv"
+)
+
+// OtherValue is another field of the input as seen by a dependent rule, see ValidationContext.OtherValue.
+// Its conversions are memoized for the validation run, so rules of many fields or wildcard items
+// referencing the same field convert it once.
+type OtherValue struct {
+	Field   string // key of the field, with the "*" of the reference resolved
+	Value   string
+	Type    string // as ValidationContext.Type
+	Present bool
+	cache   map[string]interface{}
+	number  string // the value as the numeric rules read it, see Validator.numberString
+}
+
+// OtherValue returns another field referenced by a rule argument, resolved like in Lookup. The value is
+// shared by the rules of the run; it must not be modified.
+func (ctx *ValidationContext) OtherValue(field string) *OtherValue {
+	resolved := ctx.ResolveField(field)
+	value, present := ctx.Lookup(field)
+	if other, ok := ctx.others[resolved]; ok {
+		return other
+	}
+	other := &OtherValue{Field: resolved, Value: value, Type: TypeString, Present: present, cache: make(map[string]interface{})}
+	other.number = ctx.validator.numberString(value)
+	if fieldType, ok := ctx.types[resolved]; ok {
+		other.Type = fieldType
+	}
+	if ctx.others != nil {
+		ctx.others[resolved] = other
+	}
+	return other
+}
+
+// Float returns the value parsed as a number, and whether it is numeric.
+func (o *OtherValue) Float() (float64, bool) {
+	parsed := o.parsed("float", func() interface{} {
+		if num, ok := parseNumber(o.number); ok {
+			return num
+		}
+		return nil
+	})
+	num, ok := parsed.(float64)
+	return num, ok
+}
+
+// Int returns the value parsed as an integer, and whether it is one written in decimal digits as the
+// integer rule accepts it.
+func (o *OtherValue) Int() (int64, bool) {
+	parsed := o.parsed("int", func() interface{} {
+		if !isInteger(o.number) {
+			return nil
+		}
+		n, err := strconv.ParseInt(o.number, 10, 64)
+		if err != nil {
+			return nil
+		}
+		return n
+	})
+	n, ok := parsed.(int64)
+	return n, ok
+}
+
+// parsed returns the conversion of the value cached under key, computing it with parse on first use.
+func (o *OtherValue) parsed(key string, parse func() interface{}) interface{} {
+	if value, ok := o.cache[key]; ok {
+		return value
+	}
+	value := parse()
+	o.cache[key] = value
+	return value
+}
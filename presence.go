@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"context"
+	"sync"
+)
+
+// PresenceVerifier counts the records backing the exists and unique rules. It is set with
+// Factory.SetPresenceVerifier (config key "presence_verifier") before parsing.
+//
+// where holds extra column constraints: a plain value must be equal, "!value" must differ,
+// "NULL" requires the column to be null or empty and "NOT_NULL" requires it to be set.
+type PresenceVerifier interface {
+	Count(table string, column string, value string, where map[string]string) (int, error)
+}
+
+// ContextPresenceVerifier is implemented by verifiers accepting the context given to
+// Validator.ValidateContext, e.g. to cancel queries or attach them to the caller's trace.
+type ContextPresenceVerifier interface {
+	CountContext(ctx context.Context, table string, column string, value string, where map[string]string) (int, error)
+}
+
+func countPresence(ctx *ValidationContext, verifier PresenceVerifier, table string, column string, where map[string]string) (int, error) {
+	if v, ok := verifier.(ContextPresenceVerifier); ok {
+		return v.CountContext(ctx.Context(), table, column, ctx.FieldValue, where)
+	}
+	return verifier.Count(table, column, ctx.FieldValue, where)
+}
+
+// SetPresenceVerifier sets the verifier used by the exists and unique rules.
+func (f *Factory) SetPresenceVerifier(verifier PresenceVerifier) {
+	f.SetConfig("presence_verifier", verifier)
+}
+
+// InMemoryPresenceVerifier is a PresenceVerifier over rows held in memory, meant for tests of code
+// using exists and unique without a database. It is safe for concurrent use.
+type InMemoryPresenceVerifier struct {
+	mu     sync.RWMutex
+	tables map[string][]map[string]string
+}
+
+func NewInMemoryPresenceVerifier() *InMemoryPresenceVerifier {
+	return &InMemoryPresenceVerifier{tables: make(map[string][]map[string]string)}
+}
+
+// Seed appends rows to table. Columns absent from a row are treated as null.
+func (v *InMemoryPresenceVerifier) Seed(table string, rows ...map[string]string) *InMemoryPresenceVerifier {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, row := range rows {
+		copied := make(map[string]string, len(row))
+		for column, value := range row {
+			copied[column] = value
+		}
+		v.tables[table] = append(v.tables[table], copied)
+	}
+	return v
+}
+
+// SeedValues appends one row per value, each holding only column.
+func (v *InMemoryPresenceVerifier) SeedValues(table string, column string, values ...string) *InMemoryPresenceVerifier {
+	rows := make([]map[string]string, len(values))
+	for i, value := range values {
+		rows[i] = map[string]string{column: value}
+	}
+	return v.Seed(table, rows...)
+}
+
+func (v *InMemoryPresenceVerifier) Count(table string, column string, value string, where map[string]string) (int, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	count := 0
+	for _, row := range v.tables[table] {
+		if cell, ok := row[column]; !ok || cell != value {
+			continue
+		}
+		if matchesWhere(row, where) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func matchesWhere(row map[string]string, where map[string]string) bool {
+	for column, expected := range where {
+		cell, ok := row[column]
+		switch {
+		case expected == "NULL":
+			if ok && cell != "" {
+				return false
+			}
+		case expected == "NOT_NULL":
+			if !ok || cell == "" {
+				return false
+			}
+		case len(expected) > 0 && expected[0] == '!':
+			if ok && cell == expected[1:] {
+				return false
+			}
+		default:
+			if !ok || cell != expected {
+				return false
+			}
+		}
+	}
+	return true
+}
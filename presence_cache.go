@@ -0,0 +1,119 @@
+package validation
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedPresenceVerifier wraps a PresenceVerifier with a TTL cache of the counts, keyed by table,
+// column, value and where constraints, so repeated exists checks of batch validation or hot endpoints
+// do not query the database each time. Errors are not cached. Counts may be up to ttl old: prefer
+// the wrapped verifier, or bypass the cache with WithoutPresenceCache, for unique checks guarding
+// writes. It is safe for concurrent use.
+type CachedPresenceVerifier struct {
+	verifier PresenceVerifier
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]presenceCacheEntry
+	sweepAt int
+}
+
+type presenceCacheEntry struct {
+	count   int
+	expires time.Time
+}
+
+type bypassPresenceCacheKey struct{}
+
+var (
+	_ PresenceVerifier        = (*CachedPresenceVerifier)(nil)
+	_ ContextPresenceVerifier = (*CachedPresenceVerifier)(nil)
+)
+
+func NewCachedPresenceVerifier(verifier PresenceVerifier, ttl time.Duration) *CachedPresenceVerifier {
+	return &CachedPresenceVerifier{
+		verifier: verifier,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]presenceCacheEntry),
+		sweepAt:  1024,
+	}
+}
+
+// WithoutPresenceCache returns a context making a CachedPresenceVerifier query the wrapped verifier
+// for validations run with it, e.g. validator.ValidateContext(WithoutPresenceCache(ctx), data). The
+// fresh counts still refresh the cache.
+func WithoutPresenceCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassPresenceCacheKey{}, true)
+}
+
+func (c *CachedPresenceVerifier) Count(table string, column string, value string, where map[string]string) (int, error) {
+	return c.CountContext(context.Background(), table, column, value, where)
+}
+
+func (c *CachedPresenceVerifier) CountContext(ctx context.Context, table string, column string, value string, where map[string]string) (int, error) {
+	key := presenceCacheKey(table, column, value, where)
+	if bypass, _ := ctx.Value(bypassPresenceCacheKey{}).(bool); !bypass {
+		c.mu.Lock()
+		entry, ok := c.entries[key]
+		c.mu.Unlock()
+		if ok && c.now().Before(entry.expires) {
+			return entry.count, nil
+		}
+	}
+	var count int
+	var err error
+	if verifier, ok := c.verifier.(ContextPresenceVerifier); ok {
+		count, err = verifier.CountContext(ctx, table, column, value, where)
+	} else {
+		count, err = c.verifier.Count(table, column, value, where)
+	}
+	if err != nil {
+		return 0, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if len(c.entries) >= c.sweepAt {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.sweepAt = max(1024, 2*len(c.entries))
+	}
+	c.entries[key] = presenceCacheEntry{count: count, expires: now.Add(c.ttl)}
+	return count, nil
+}
+
+// Flush empties the cache, e.g. after writes making cached counts stale.
+func (c *CachedPresenceVerifier) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]presenceCacheEntry)
+}
+
+func presenceCacheKey(table string, column string, value string, where map[string]string) string {
+	var sb strings.Builder
+	for _, part := range []string{table, column, value} {
+		sb.WriteString(part)
+		sb.WriteByte(0)
+	}
+	columns := make([]string, 0, len(where))
+	for whereColumn := range where {
+		columns = append(columns, whereColumn)
+	}
+	sort.Strings(columns)
+	for _, whereColumn := range columns {
+		sb.WriteString(whereColumn)
+		sb.WriteByte(0)
+		sb.WriteString(where[whereColumn])
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
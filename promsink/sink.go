@@ -0,0 +1,79 @@
+// Package promsink implements validation.MetricsSink with Prometheus histograms. It lives in its
+// own module so the core package does not depend on the Prometheus client.
+package promsink
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shugen002/validation"
+)
+
+// Sink exports, under the given namespace:
+//   - validation_duration_seconds{result}: latency of Validate calls
+//   - validation_rules_per_run: number of rules run by a Validate call
+//   - validation_rule_duration_seconds{rule,result}: latency of each rule, whose count per
+//     result="failed" gives the failure hot spots
+//
+// result is "passed" or "failed". Register the sink itself, e.g. prometheus.MustRegister(sink).
+type Sink struct {
+	validations  *prometheus.HistogramVec
+	rulesPerRun  prometheus.Histogram
+	ruleDuration *prometheus.HistogramVec
+}
+
+var (
+	_ validation.MetricsSink = (*Sink)(nil)
+	_ prometheus.Collector   = (*Sink)(nil)
+)
+
+func New(namespace string) *Sink {
+	return &Sink{
+		validations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "validation_duration_seconds",
+			Help:      "Duration of validation runs.",
+			Buckets:   []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05, .1, .5, 1},
+		}, []string{"result"}),
+		rulesPerRun: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "validation_rules_per_run",
+			Help:      "Number of rules run per validation.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+		}),
+		ruleDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "validation_rule_duration_seconds",
+			Help:      "Duration of single rule runs.",
+			Buckets:   []float64{.000001, .000005, .00001, .00005, .0001, .0005, .001, .01, .1, 1},
+		}, []string{"rule", "result"}),
+	}
+}
+
+func result(failed bool) string {
+	if failed {
+		return "failed"
+	}
+	return "passed"
+}
+
+func (s *Sink) ObserveValidation(rulesCount int, duration time.Duration, failed bool) {
+	s.validations.WithLabelValues(result(failed)).Observe(duration.Seconds())
+	s.rulesPerRun.Observe(float64(rulesCount))
+}
+
+func (s *Sink) ObserveRule(rule string, duration time.Duration, failed bool) {
+	s.ruleDuration.WithLabelValues(rule, result(failed)).Observe(duration.Seconds())
+}
+
+func (s *Sink) Describe(ch chan<- *prometheus.Desc) {
+	s.validations.Describe(ch)
+	s.rulesPerRun.Describe(ch)
+	s.ruleDuration.Describe(ch)
+}
+
+func (s *Sink) Collect(ch chan<- prometheus.Metric) {
+	s.validations.Collect(ch)
+	s.rulesPerRun.Collect(ch)
+	s.ruleDuration.Collect(ch)
+}
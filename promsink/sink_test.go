@@ -0,0 +1,32 @@
+package promsink
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shugen002/validation"
+)
+
+func TestSink(t *testing.T) {
+	sink := New("app")
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(sink)
+
+	factory := validation.NewFactory()
+	factory.SetMetricsSink(sink)
+	validator, err := factory.Parse(map[string]string{"email": "required|email"})
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %v", err)
+	}
+	validator.Validate(map[string]string{"email": "ann@example.com"})
+	validator.Validate(map[string]string{"email": "not an email"})
+	validator.Validate(map[string]string{})
+
+	if count := testutil.CollectAndCount(sink, "app_validation_duration_seconds"); count != 2 {
+		t.Errorf("Expected passed and failed validation series, got %d", count)
+	}
+	if count := testutil.CollectAndCount(sink, "app_validation_rule_duration_seconds"); count != 4 {
+		t.Errorf("Expected passed and failed series for required and email, got %d", count)
+	}
+}
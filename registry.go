@@ -0,0 +1,154 @@
+package validation
+
+import (
+	"slices"
+	"sort"
+)
+
+// RuleInfo describes a rule for schema exporters, CLIs and documentation generators, see Rules.
+type RuleInfo struct {
+	Name string
+	// Signature is the rule as written with placeholder arguments, e.g. "between:min,max". A trailing
+	// "..." means the last argument repeats. Custom rules have their name as signature.
+	Signature string
+	// Implicit rules check the presence of the field, they run on missing and null values, even for
+	// nullable fields when written before nullable.
+	Implicit bool
+	// Dependent rules refer to other fields, see ValidationContext.Lookup.
+	Dependent bool
+	// MessageKey is the rule part of the SetMessages keys overriding the rule message, as in
+	// "email.<MessageKey>". It is empty for rules which never fail.
+	MessageKey string
+	// Custom is set for rules added with Factory.RegisterRule or replacing a built-in rule.
+	Custom bool
+}
+
+// ruleSignatures holds the signature of the built-in rules taking arguments.
+var ruleSignatures = map[string]string{
+	"accepted_if":          "accepted_if:anotherfield,value,...",
+	"after":                "after:date_or_field,[precision=date]",
+	"age":                  "age:[min=value],[max=value]",
+	"after_or_equal":       "after_or_equal:date_or_field,[precision=date]",
+	"array":                "array",
+	"array_keys_in":        "array_keys_in:foo,...",
+	"array_keys_regex":     "array_keys_regex:pattern",
+	"before":               "before:date_or_field,[precision=date]",
+	"before_or_equal":      "before_or_equal:date_or_field,[precision=date]",
+	"between":              "between:min,max,[bytes|runes|graphemes]",
+	"cidr":                 "cidr:[ipv4,ipv6,strict]",
+	"color":                "color:[hex,rgb,hsl,named]",
+	"decimal":              "decimal:min,max,[trim_zeros]",
+	"declined_if":          "declined_if:anotherfield,value,...",
+	"different":            "different:field",
+	"distinct_with":        "distinct_with:anotherfield,...",
+	"date":                 "date:[iso8601|rfc3339]",
+	"date_before_days":     "date_before_days:days",
+	"date_equals":          "date_equals:date_or_field,[precision=date]",
+	"date_weekday":         "date_weekday:day,...",
+	"digits":               "digits:value",
+	"digits_between":       "digits_between:min,max",
+	"doesnt_end_with":      "doesnt_end_with:foo,...",
+	"doesnt_start_with":    "doesnt_start_with:foo,...",
+	"duration":             "duration:[min=value,max=value]",
+	"ends_with":            "ends_with:foo,...",
+	"exists":               "exists:table,column,where_column,where_value,...",
+	"gt":                   "gt:field_or_value",
+	"gte":                  "gte:field_or_value",
+	"handle":               "handle:[min=value,max=value,charset=value]",
+	"hash":                 "hash:algorithm,...",
+	"iends_with":           "iends_with:foo,...",
+	"css_color":            "css_color:[hex,rgb,hsl,named]",
+	"css_length":           "css_length:[unit,...]",
+	"format":               "format:name",
+	"in":                   "in:foo,...",
+	"int":                  "int:[strict|lenient]",
+	"integer":              "integer:[strict|lenient]",
+	"ip":                   "ip:[public,private,not_loopback]",
+	"istarts_with":         "istarts_with:foo,...",
+	"json":                 "json:[max_depth=value,max_bytes=value]",
+	"lowercase":            "lowercase:[strict]",
+	"lt":                   "lt:field_or_value",
+	"lte":                  "lte:field_or_value",
+	"max":                  "max:value,[bytes|runes|graphemes]",
+	"max_digits":           "max_digits:value",
+	"mime_type_string":     "mime_type_string:type,...",
+	"min":                  "min:value,[bytes|runes|graphemes]",
+	"min_entropy":          "min_entropy:bits",
+	"min_digits":           "min_digits:value",
+	"missing_if":           "missing_if:anotherfield,value,...",
+	"missing_unless":       "missing_unless:anotherfield,value,...",
+	"missing_with":         "missing_with:field,...",
+	"missing_with_all":     "missing_with_all:field,...",
+	"not_in_blocklist":     "not_in_blocklist:listname,[match=contains|word]",
+	"not_similar_to":       "not_similar_to:field,[threshold]",
+	"not_in":               "not_in:foo,...",
+	"not_regex":            "not_regex:pattern",
+	"path":                 "path:[absolute,relative,clean]",
+	"port":                 "port:[no_well_known]",
+	"postal_code":          "postal_code:country,...",
+	"postal_code_with":     "postal_code_with:country_field",
+	"present_if":           "present_if:anotherfield,value,...",
+	"present_unless":       "present_unless:anotherfield,value,...",
+	"present_with":         "present_with:field,...",
+	"present_with_all":     "present_with_all:field,...",
+	"prohibits":            "prohibits:field,...",
+	"regex":                "regex:pattern",
+	"required_if":          "required_if:anotherfield,value,...",
+	"required_if_accepted": "required_if_accepted:field,...",
+	"required_if_context":  "required_if_context:key,value,...",
+	"required_if_declined": "required_if_declined:field,...",
+	"required_unless":      "required_unless:anotherfield,value,...",
+	"required_with":        "required_with:field,...",
+	"required_with_all":    "required_with_all:field,...",
+	"required_without":     "required_without:field,...",
+	"required_without_all": "required_without_all:field,...",
+	"same":                 "same:field",
+	"sanitize":             "sanitize:sanitizer,...",
+	"size":                 "size:value,[bytes|runes|graphemes]",
+	"string":               "string:[convert]",
+	"starts_with":          "starts_with:foo,...",
+	"unique":               "unique:table,column,except,id_column,where_column,where_value,...",
+	"uppercase":            "uppercase:[strict]",
+	"url":                  "url:[scheme,...,no_credentials,max=value]",
+	"within_window":        "within_window:start_date_or_field,end_date_or_field,[tz=location]",
+}
+
+// silentRules never fail, they only change how the other rules run.
+var silentRules = []string{"bail", "exclude", "exclude_if", "exclude_unless", "nullable", "sanitize", "sometimes"}
+
+// Rules describes the built-in rules, sorted by name. Optional arguments are in brackets in signatures.
+func Rules() []RuleInfo {
+	return NewFactory().Rules()
+}
+
+// Rules describes the rules of the factory, built-in and custom, sorted by name.
+func (f *Factory) Rules() []RuleInfo {
+	names := make([]string, 0, len(f.rules)+1)
+	for name := range f.rules {
+		names = append(names, name)
+	}
+	if _, ok := f.rules["sanitize"]; !ok {
+		names = append(names, "sanitize")
+	}
+	sort.Strings(names)
+	infos := make([]RuleInfo, 0, len(names))
+	for _, name := range names {
+		info := RuleInfo{Name: name, Signature: name, MessageKey: name}
+		if signature, ok := ruleSignatures[name]; ok {
+			info.Signature = signature
+		}
+		info.Implicit = slices.Contains(implicitRules, name)
+		_, info.Dependent = fieldReferences[name]
+		if name == "confirmed" {
+			info.Dependent = true
+		}
+		if slices.Contains(silentRules, name) {
+			info.MessageKey = ""
+		}
+		if slices.Contains(f.customRules, name) {
+			info = RuleInfo{Name: name, Signature: name, MessageKey: name, Custom: true}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
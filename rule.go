@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Rule is a simpler contract for custom rules than RuleConstructor. Validate receives the field name,
+// its value (nil when the key is absent) and the whole input, and reports failures through fail,
+// which may be called several times. messageKey is the message template, or the rule part of a key
+// given to SetMessages ("field.messageKey") which then takes precedence. params are "name=value"
+// pairs replacing ":name" in the message; ":attribute" and ":input" are always available.
+type Rule interface {
+	Validate(attribute string, value interface{}, data map[string]interface{}, fail func(messageKey string, params ...string))
+}
+
+// DataAwareRule is implemented by rules that need the whole input before Validate is called.
+type DataAwareRule interface {
+	SetData(data map[string]interface{})
+}
+
+// ValidatorAwareRule is implemented by rules that need the running Validator, e.g. to read its
+// attribute names.
+type ValidatorAwareRule interface {
+	SetValidator(validator *Validator)
+}
+
+// cloneRule returns a shallow copy of a rule held by pointer, so that data and validator injected
+// into it stay local to one validation. Rules held by value are already copied on each call.
+func cloneRule(rule Rule) Rule {
+	value := reflect.ValueOf(rule)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return rule
+	}
+	clone := reflect.New(value.Elem().Type())
+	clone.Elem().Set(value.Elem())
+	return clone.Interface().(Rule)
+}
+
+// AdaptRule turns a Rule into a RuleConstructor so it can be registered with Factory.RegisterRule.
+// A failing rule stops the remaining rules of the field; all its messages are joined into the error.
+// Rules implementing DataAwareRule or ValidatorAwareRule are copied for each field validated and
+// receive the input and the validator through those interfaces, so one instance can be shared safely.
+func AdaptRule(rule Rule) RuleConstructor {
+	return func(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+		return func(ctx *ValidationContext) (bool, error) {
+			data := ctx.data()
+			var value interface{}
+			if raw, ok := ctx.Raw[ctx.FieldName]; ok {
+				value = raw
+			}
+			rule := rule
+			_, isDataAware := rule.(DataAwareRule)
+			_, isValidatorAware := rule.(ValidatorAwareRule)
+			if isDataAware || isValidatorAware {
+				rule = cloneRule(rule)
+			}
+			if r, ok := rule.(DataAwareRule); ok {
+				r.SetData(data)
+			}
+			if r, ok := rule.(ValidatorAwareRule); ok {
+				r.SetValidator(ctx.validator)
+			}
+			var errs []error
+			rule.Validate(ctx.FieldName, value, data, func(messageKey string, params ...string) {
+				errs = append(errs, errors.New(ctx.failMessage(messageKey, params)))
+			})
+			if len(errs) > 0 {
+				return false, errors.Join(errs...)
+			}
+			return true, nil
+		}, nil
+	}
+}
+
+// data returns the input as given to Rule.Validate.
+func (ctx *ValidationContext) data() map[string]interface{} {
+	data := make(map[string]interface{}, len(ctx.Raw))
+	for key, value := range ctx.Raw {
+		data[key] = value
+	}
+	return data
+}
+
+// RequiredWhen returns a rule requiring the field to be present and not empty when condition holds
+// for the input, like Laravel's Rule::requiredIf(fn), for conditions too complex for required_if.
+// Register it under a name: factory.RegisterRule("required_for_business", RequiredWhen(isBusiness)).
+// condition receives the input as Rule.Validate does.
+func RequiredWhen(condition func(data map[string]interface{}) bool) RuleConstructor {
+	return func(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+		return func(ctx *ValidationContext) (bool, error) {
+			if !IsEmptyValue(ctx.FieldValue) {
+				return true, nil
+			}
+			if condition(ctx.data()) {
+				return false, fmt.Errorf("%s is required", ctx.FieldName)
+			}
+			return false, nil
+		}, nil
+	}
+}
+
+// ProhibitedWhen returns a rule requiring the field to be missing or empty when condition holds for
+// the input, like Laravel's Rule::prohibitedIf(fn). It is registered like RequiredWhen.
+func ProhibitedWhen(condition func(data map[string]interface{}) bool) RuleConstructor {
+	return func(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+		return func(ctx *ValidationContext) (bool, error) {
+			if !IsEmptyValue(ctx.FieldValue) && condition(ctx.data()) {
+				return false, fmt.Errorf("%s is prohibited", ctx.FieldName)
+			}
+			return true, nil
+		}, nil
+	}
+}
+
+func (ctx *ValidationContext) failMessage(messageKey string, params []string) string {
+	message, ok := ctx.customMessage(messageKey)
+	if !ok {
+		message = strings.NewReplacer(
+			":attribute", ctx.Attribute(ctx.FieldName),
+			":input", ctx.Input(),
+		).Replace(messageKey)
+	}
+	return replaceMessageParams(message, params)
+}
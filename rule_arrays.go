@@ -0,0 +1,166 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// arrayKeys returns the sorted keys of the elements of the field: the indexes of a slice or the keys
+// of a map given to ValidateData, or the segments following the field name in flat input, so
+// "translations.en" and "translations.fr" give ["en", "fr"].
+func (ctx *ValidationContext) arrayKeys() []string {
+	return ctx.parsed("array_keys", func() interface{} {
+		var keys []string
+		for _, input := range []map[string]string{ctx.Raw, ctx.types} {
+			for key := range input {
+				if rest, ok := strings.CutPrefix(key, ctx.FieldName+"."); ok {
+					element, _, _ := strings.Cut(rest, ".")
+					if !slices.Contains(keys, element) {
+						keys = append(keys, element)
+					}
+				}
+			}
+		}
+		slices.Sort(keys)
+		return keys
+	}).([]string)
+}
+
+// arrayLength returns the number of elements of the field when it is an array: a slice, array or map
+// given to ValidateData, or in flat input a field having the array rule, whose elements are the keys
+// "field.0", "field.1", ... of the input.
+func (ctx *ValidationContext) arrayLength() (int, bool) {
+	if ctx.Type != TypeArray && ctx.Type != TypeMap && !slices.Contains(ctx.Rules, "array") {
+		return 0, false
+	}
+	return len(ctx.arrayKeys()), true
+}
+
+// isPresent reports whether the field exists in the input, even empty: as a key, or as an array given
+// to ValidateData or through element keys such as "items.0".
+func (ctx *ValidationContext) isPresent() bool {
+	if IsPresent(ctx.Raw, ctx.FieldName) || IsPresent(ctx.types, ctx.FieldName) {
+		return true
+	}
+	return len(ctx.arrayKeys()) > 0
+}
+
+// isEmpty reports whether the field counts as empty for the presence rules, see IsEmptyValue; an
+// array is empty when it has no elements.
+func (ctx *ValidationContext) isEmpty() bool {
+	if n, ok := ctx.arrayLength(); ok && (ctx.Type != TypeString || !IsPresent(ctx.Raw, ctx.FieldName)) {
+		return n == 0
+	}
+	return IsEmptyValue(ctx.FieldValue)
+}
+
+// array
+// The field under validation must be an array: a slice, array or map given to ValidateData, or in
+// flat input a field given only through element keys such as "items.0". The size rules then count its
+// elements.
+func constructArray(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) > 0 {
+		return nil, fmt.Errorf("array rule takes no arguments")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		if ctx.Type == TypeArray || ctx.Type == TypeMap {
+			return true, nil
+		}
+		if n, _ := ctx.arrayLength(); ctx.Type == TypeString && !IsPresent(ctx.Raw, ctx.FieldName) && n > 0 {
+			return true, nil
+		}
+		return false, fmt.Errorf("the %s field must be an array", ctx.FieldName)
+	}, nil
+}
+
+// arrayKeysRule returns a rule checking every key of the array under validation with valid; a field
+// given as a plain value fails.
+func arrayKeysRule(valid func(key string) bool, message string) ValidationRule {
+	return func(ctx *ValidationContext) (bool, error) {
+		keys := ctx.arrayKeys()
+		if len(keys) == 0 && !IsEmptyValue(ctx.FieldValue) {
+			return false, fmt.Errorf("the %s field must be an array", ctx.FieldName)
+		}
+		for _, key := range keys {
+			if !valid(key) {
+				return false, fmt.Errorf(message, ctx.FieldName, key)
+			}
+		}
+		return true, nil
+	}
+}
+
+// array_keys_regex:pattern
+// The keys of the array under validation, not its values, must match the regular expression, written
+// like for regex: array_keys_regex:/^[a-z]{2}$/ for {"translations": {"en": "...", "fr": "..."}}.
+func constructArrayKeysRegex(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	re, err := compileRegexArgs("array_keys_regex", args)
+	if err != nil {
+		return nil, err
+	}
+	return arrayKeysRule(re.MatchString, "the %s field has an invalid key: %s"), nil
+}
+
+// array_keys_in:foo,bar,...
+// The keys of the array under validation, not its values, must be among the given ones.
+func constructArrayKeysIn(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("array_keys_in rule requires at least 1 argument")
+	}
+	allowed := slices.Clone(args)
+	return arrayKeysRule(func(key string) bool { return slices.Contains(allowed, key) },
+		"the %s field has a key that is not allowed: %s"), nil
+}
+
+// distinct_with:anotherfield,...
+// Within a wildcard group, the field under validation together with the other fields of the same
+// element must not repeat the combination of another element: with "items.*.sku" having
+// distinct_with:items.*.warehouse, two items may share a sku or a warehouse but not both. The other
+// fields are written with "*" like the field, elements without the field are left out, and missing
+// other fields count as empty. Outside of a wildcard group the rule always passes.
+func constructDistinctWith(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("distinct_with rule requires at least 1 argument")
+	}
+	// combination identifies the element bound to wildcards by its group, the wildcards of the
+	// enclosing elements, and the values of the field and the other fields
+	combination := func(ctx *ValidationContext, wildcards []string) string {
+		values := append([]string{}, wildcards[:len(wildcards)-1]...)
+		values = append(values, ctx.Raw[bindWildcards(ctx.pattern, wildcards)])
+		for _, other := range args {
+			values = append(values, ctx.Raw[bindWildcards(other, wildcards)])
+		}
+		return strings.Join(values, "\x00")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		if len(ctx.wildcards) == 0 {
+			return true, nil
+		}
+		// the combinations of every element are counted once per run, not once per element
+		counts := ctx.shared("distinct_with\x00"+ctx.pattern+"\x00"+strings.Join(args, ","), func() interface{} {
+			counts := make(map[string]int)
+			for _, element := range expandWildcard(ctx.pattern, ctx.Raw) {
+				if IsPresent(ctx.Raw, element) {
+					counts[combination(ctx, wildcardValues(ctx.pattern, element))]++
+				}
+			}
+			return counts
+		}).(map[string]int)
+		if counts[combination(ctx, ctx.wildcards)] > 1 {
+			attributes := make([]string, len(args))
+			for i, other := range args {
+				attributes[i] = ctx.Attribute(other)
+			}
+			return false, fmt.Errorf("the %s field has a duplicate value in combination with %s", ctx.FieldName, strings.Join(attributes, ", "))
+		}
+		return true, nil
+	}, nil
+}
+
+var embeddedArrayRules = map[string]RuleConstructor{
+	"array":            constructArray,
+	"array_keys_in":    constructArrayKeysIn,
+	"array_keys_regex": constructArrayKeysRegex,
+	"distinct_with":    constructDistinctWith,
+}
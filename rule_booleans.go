@@ -12,12 +12,42 @@ import (
 // Declined
 // Declined If
 
+// Accepted and declined rules are implicit like in Laravel: a missing field fails them unless the chain
+// starts with sometimes. Setting the "accepted_allows_missing" config to true restores the lenient
+// behavior where a missing field passes these rules.
+func allowsMissing(cfg map[string]interface{}, ctx *ValidationContext) bool {
+	if lenient, _ := cfg["accepted_allows_missing"].(bool); !lenient {
+		return false
+	}
+	return !ctx.isPresent()
+}
+
+// isAccepted reports whether value is "yes", "on", "1" or "true", case-insensitively.
+func isAccepted(value string) bool {
+	switch strings.ToLower(value) {
+	case "yes", "on", "1", "true":
+		return true
+	}
+	return false
+}
+
+// isDeclined reports whether value is "no", "off", "0" or "false", case-insensitively.
+func isDeclined(value string) bool {
+	switch strings.ToLower(value) {
+	case "no", "off", "0", "false":
+		return true
+	}
+	return false
+}
+
 // accepted
 // The field under validation must be "yes", "on", 1, "1", true, or "true". This is useful for validating "Terms of Service" acceptance or similar fields.
-func constructAcceptedRule(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+func constructAcceptedRule(cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
 	return func(ctx *ValidationContext) (bool, error) {
-		val := strings.ToLower(ctx.FieldValue)
-		if val == "yes" || val == "on" || val == "1" || val == "true" {
+		if allowsMissing(cfg, ctx) {
+			return true, nil
+		}
+		if isAccepted(ctx.FieldValue) {
 			return true, nil
 		}
 		return false, fmt.Errorf("the %s field must be accepted", ctx.FieldName)
@@ -34,23 +64,17 @@ func constructAcceptedIfRule(cfg map[string]interface{}, args ...string) (Valida
 	expectedValues := args[1:]
 
 	return func(ctx *ValidationContext) (bool, error) {
-		otherValue, ok := ctx.Raw[otherField]
-		if !ok {
-			return false, fmt.Errorf("the %s field is not present", otherField)
+		if _, ok := ctx.Lookup(otherField); !ok {
+			return false, fmt.Errorf("the %s field is not present", ctx.Attribute(otherField))
 		}
-		match := false
-		for _, v := range expectedValues {
-			if otherValue == v {
-				match = true
-				break
+		if fieldEquals(ctx, otherField, expectedValues) {
+			if allowsMissing(cfg, ctx) {
+				return true, nil
 			}
-		}
-		if match {
-			val := strings.ToLower(ctx.FieldValue)
-			if val == "yes" || val == "on" || val == "1" || val == "true" {
+			if isAccepted(ctx.FieldValue) {
 				return true, nil
 			}
-			return false, fmt.Errorf("the %s field must be accepted when %s is %s", ctx.FieldName, otherField, strings.Join(expectedValues, ", "))
+			return false, fmt.Errorf("the %s field must be accepted when %s is %s", ctx.FieldName, ctx.Attribute(otherField), strings.Join(expectedValues, ", "))
 		}
 		return true, nil
 	}, nil
@@ -85,10 +109,12 @@ func constructBooleanRule(cfg map[string]interface{}, args ...string) (Validatio
 // declined
 // The field under validation must be "no", "off", 0, "0", false, or "false".
 
-func constructDeclinedRule(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+func constructDeclinedRule(cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
 	return func(ctx *ValidationContext) (bool, error) {
-		val := strings.ToLower(ctx.FieldValue)
-		if val == "no" || val == "off" || val == "0" || val == "false" {
+		if allowsMissing(cfg, ctx) {
+			return true, nil
+		}
+		if isDeclined(ctx.FieldValue) {
 			return true, nil
 		}
 		return false, fmt.Errorf("the %s field must be declined", ctx.FieldName)
@@ -105,23 +131,17 @@ func constructDeclinedIfRule(cfg map[string]interface{}, args ...string) (Valida
 	expectedValues := args[1:]
 
 	return func(ctx *ValidationContext) (bool, error) {
-		otherValue, ok := ctx.Raw[otherField]
-		if !ok {
-			return false, fmt.Errorf("the %s field is not present", otherField)
+		if _, ok := ctx.Lookup(otherField); !ok {
+			return false, fmt.Errorf("the %s field is not present", ctx.Attribute(otherField))
 		}
-		match := false
-		for _, v := range expectedValues {
-			if otherValue == v {
-				match = true
-				break
+		if fieldEquals(ctx, otherField, expectedValues) {
+			if allowsMissing(cfg, ctx) {
+				return true, nil
 			}
-		}
-		if match {
-			val := strings.ToLower(ctx.FieldValue)
-			if val == "no" || val == "off" || val == "0" || val == "false" {
+			if isDeclined(ctx.FieldValue) {
 				return true, nil
 			}
-			return false, fmt.Errorf("the %s field must be declined when %s is %s", ctx.FieldName, otherField, strings.Join(expectedValues, ", "))
+			return false, fmt.Errorf("the %s field must be declined when %s is %s", ctx.FieldName, ctx.Attribute(otherField), strings.Join(expectedValues, ", "))
 		}
 		return true, nil
 	}, nil
@@ -0,0 +1,220 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Colors:
+// Hex Color
+// Color
+// CSS Color
+
+// Laravel accepts 3 or 6 digit colors, optionally followed by an alpha channel in the 8 digit form (#RRGGBBAA).
+var hexColorRegexp = regexp.MustCompile(`^#([a-fA-F0-9]{3}|[a-fA-F0-9]{6}|[a-fA-F0-9]{8})$`)
+
+func isHexColor(str string) bool {
+	return hexColorRegexp.MatchString(str)
+}
+
+var namedColors = map[string]struct{}{}
+
+func init() {
+	for _, name := range strings.Fields(`
+		aliceblue antiquewhite aqua aquamarine azure beige bisque black blanchedalmond blue blueviolet brown
+		burlywood cadetblue chartreuse chocolate coral cornflowerblue cornsilk crimson cyan darkblue darkcyan
+		darkgoldenrod darkgray darkgreen darkgrey darkkhaki darkmagenta darkolivegreen darkorange darkorchid
+		darkred darksalmon darkseagreen darkslateblue darkslategray darkslategrey darkturquoise darkviolet
+		deeppink deepskyblue dimgray dimgrey dodgerblue firebrick floralwhite forestgreen fuchsia gainsboro
+		ghostwhite gold goldenrod gray green greenyellow grey honeydew hotpink indianred indigo ivory khaki
+		lavender lavenderblush lawngreen lemonchiffon lightblue lightcoral lightcyan lightgoldenrodyellow
+		lightgray lightgreen lightgrey lightpink lightsalmon lightseagreen lightskyblue lightslategray
+		lightslategrey lightsteelblue lightyellow lime limegreen linen magenta maroon mediumaquamarine
+		mediumblue mediumorchid mediumpurple mediumseagreen mediumslateblue mediumspringgreen
+		mediumturquoise mediumvioletred midnightblue mintcream mistyrose moccasin navajowhite navy oldlace
+		olive olivedrab orange orangered orchid palegoldenrod palegreen paleturquoise palevioletred
+		papayawhip peachpuff peru pink plum powderblue purple rebeccapurple red rosybrown royalblue
+		saddlebrown salmon sandybrown seagreen seashell sienna silver skyblue slateblue slategray slategrey
+		snow springgreen steelblue tan teal thistle tomato turquoise violet wheat white whitesmoke yellow
+		yellowgreen transparent`) {
+		namedColors[name] = struct{}{}
+	}
+}
+
+func isNamedColor(str string) bool {
+	_, ok := namedColors[strings.ToLower(str)]
+	return ok
+}
+
+// parseColorFunction splits "name(a, b, c / d)" into its function name and components.
+// Both the legacy comma syntax and the modern space separated syntax are accepted.
+func parseColorFunction(str string) (name string, channels []string, alpha string, ok bool) {
+	str = strings.TrimSpace(strings.ToLower(str))
+	open := strings.IndexByte(str, '(')
+	if open <= 0 || !strings.HasSuffix(str, ")") {
+		return "", nil, "", false
+	}
+	name = str[:open]
+	body := str[open+1 : len(str)-1]
+	if strings.Contains(body, ",") {
+		parts := strings.Split(body, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		if len(parts) == 4 {
+			return name, parts[:3], parts[3], true
+		}
+		return name, parts, "", true
+	}
+	if slash := strings.IndexByte(body, '/'); slash >= 0 {
+		alpha = strings.TrimSpace(body[slash+1:])
+		if alpha == "" {
+			return "", nil, "", false
+		}
+		body = body[:slash]
+	}
+	return name, strings.Fields(body), alpha, true
+}
+
+// parseColorNumber parses a number optionally suffixed by unit, reporting whether the unit was present.
+func parseColorNumber(str, unit string) (float64, bool, bool) {
+	hasUnit := unit != "" && strings.HasSuffix(str, unit)
+	if hasUnit {
+		str = strings.TrimSuffix(str, unit)
+	}
+	num, err := strconv.ParseFloat(str, 64)
+	if err != nil || str == "" || strings.ContainsAny(str, "eE") {
+		return 0, false, false
+	}
+	return num, hasUnit, true
+}
+
+func isColorAlpha(str string) bool {
+	num, percent, ok := parseColorNumber(str, "%")
+	if !ok {
+		return false
+	}
+	if percent {
+		return num >= 0 && num <= 100
+	}
+	return num >= 0 && num <= 1
+}
+
+func isRGBColor(str string) bool {
+	name, channels, alpha, ok := parseColorFunction(str)
+	if !ok || (name != "rgb" && name != "rgba") || len(channels) != 3 {
+		return false
+	}
+	for _, channel := range channels {
+		num, percent, ok := parseColorNumber(channel, "%")
+		if !ok || num < 0 || (percent && num > 100) || (!percent && num > 255) {
+			return false
+		}
+	}
+	return alpha == "" || isColorAlpha(alpha)
+}
+
+func isHSLColor(str string) bool {
+	name, channels, alpha, ok := parseColorFunction(str)
+	if !ok || (name != "hsl" && name != "hsla") || len(channels) != 3 {
+		return false
+	}
+	if _, _, ok := parseColorNumber(channels[0], "deg"); !ok {
+		return false
+	}
+	for _, channel := range channels[1:] {
+		num, percent, ok := parseColorNumber(channel, "%")
+		if !ok || !percent || num < 0 || num > 100 {
+			return false
+		}
+	}
+	return alpha == "" || isColorAlpha(alpha)
+}
+
+var colorFormats = map[string]func(string) bool{
+	"hex":   isHexColor,
+	"rgb":   isRGBColor,
+	"hsl":   isHSLColor,
+	"named": isNamedColor,
+}
+
+// hex_color
+// The field under validation must contain a valid color value in hexadecimal format.
+func constructHexColor(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+	return func(ctx *ValidationContext) (bool, error) {
+		if !isHexColor(ctx.FieldValue) {
+			return false, fmt.Errorf("the %s field must be a valid hexadecimal color", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+// color:hex,rgb,hsl,named
+// The field under validation must be a color in one of the given formats. All formats are accepted when none are given.
+func constructColor(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	formats := []string{"hex", "rgb", "hsl", "named"}
+	if len(args) > 0 {
+		formats = make([]string, 0, len(args))
+		for _, arg := range args {
+			format := strings.ToLower(strings.TrimSpace(arg))
+			if _, ok := colorFormats[format]; !ok {
+				return nil, fmt.Errorf("invalid color format: %s", arg)
+			}
+			formats = append(formats, format)
+		}
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		for _, format := range formats {
+			if colorFormats[format](ctx.FieldValue) {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("the %s field must be a valid color (%s)", ctx.FieldName, strings.Join(formats, ", "))
+	}, nil
+}
+
+// CSS also accepts the 4 digit form #RGBA.
+var cssHexColorRegexp = regexp.MustCompile(`^#([a-fA-F0-9]{3,4}|[a-fA-F0-9]{6}|[a-fA-F0-9]{8})$`)
+
+var cssColorFormats = map[string]func(string) bool{
+	"hex": func(str string) bool { return cssHexColorRegexp.MatchString(str) },
+	"rgb": isRGBColor,
+	"hsl": isHSLColor,
+	"named": func(str string) bool {
+		return isNamedColor(str) || strings.EqualFold(str, "currentcolor")
+	},
+}
+
+// css_color:hex,rgb,hsl,named
+// The field under validation must be a CSS color value in one of the given formats, all of them when
+// none are given. Unlike color, it accepts what browsers do: the #RGBA hex form and the currentcolor
+// keyword.
+func constructCSSColor(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	formats := []string{"hex", "rgb", "hsl", "named"}
+	if len(args) > 0 {
+		formats = make([]string, 0, len(args))
+		for _, arg := range args {
+			format := strings.ToLower(strings.TrimSpace(arg))
+			if _, ok := cssColorFormats[format]; !ok {
+				return nil, fmt.Errorf("invalid css_color format: %s", arg)
+			}
+			formats = append(formats, format)
+		}
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		for _, format := range formats {
+			if cssColorFormats[format](ctx.FieldValue) {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("the %s field must be a valid CSS color (%s)", ctx.FieldName, strings.Join(formats, ", "))
+	}, nil
+}
+
+var embeddedColorRules = map[string]RuleConstructor{
+	"hex_color": constructHexColor,
+	"color":     constructColor,
+	"css_color": constructCSSColor,
+}
@@ -0,0 +1,122 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Database:
+// Exists
+// Unique
+
+func presenceVerifier(cfg map[string]interface{}, rule string) (PresenceVerifier, error) {
+	verifier, ok := cfg["presence_verifier"].(PresenceVerifier)
+	if !ok || verifier == nil {
+		return nil, fmt.Errorf("%s rule requires a presence verifier", rule)
+	}
+	return verifier, nil
+}
+
+// parseWhere reads trailing "column,value" pairs of the exists and unique rules.
+func parseWhere(rule string, args []string) (map[string]string, error) {
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("%s rule requires where clauses as column,value pairs", rule)
+	}
+	where := make(map[string]string, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		where[strings.TrimSpace(args[i])] = strings.TrimSpace(args[i+1])
+	}
+	return where, nil
+}
+
+// presenceColumn returns the rule column, defaulting to the last segment of the field name.
+func presenceColumn(ctx *ValidationContext, column string) string {
+	if column != "" {
+		return column
+	}
+	if i := strings.LastIndex(ctx.FieldName, "."); i >= 0 {
+		return ctx.FieldName[i+1:]
+	}
+	return ctx.FieldName
+}
+
+// exists:table,column,where_column,where_value,...
+// The field under validation must exist in the given table. The column defaults to the field name;
+// further column,value pairs constrain the matching records (see PresenceVerifier).
+func constructExists(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 || strings.TrimSpace(args[0]) == "" {
+		return nil, fmt.Errorf("exists rule requires a table argument")
+	}
+	verifier, err := presenceVerifier(cfg, "exists")
+	if err != nil {
+		return nil, err
+	}
+	table, column := strings.TrimSpace(args[0]), ""
+	if len(args) > 1 {
+		column = strings.TrimSpace(args[1])
+	}
+	var where map[string]string
+	if len(args) > 2 {
+		if where, err = parseWhere("exists", args[2:]); err != nil {
+			return nil, err
+		}
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		count, err := countPresence(ctx, verifier, table, presenceColumn(ctx, column), where)
+		if err != nil {
+			return false, fmt.Errorf("unable to verify the %s field: %w", ctx.FieldName, err)
+		}
+		if count == 0 {
+			return false, fmt.Errorf("the selected %s is invalid", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+// unique:table,column,except,id_column,where_column,where_value,...
+// The field under validation must not exist in the given table. The column defaults to the field name.
+// except ignores the record whose id_column (default "id") has that value, e.g. the record being updated;
+// further column,value pairs constrain the records considered (see PresenceVerifier).
+func constructUnique(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 || strings.TrimSpace(args[0]) == "" {
+		return nil, fmt.Errorf("unique rule requires a table argument")
+	}
+	verifier, err := presenceVerifier(cfg, "unique")
+	if err != nil {
+		return nil, err
+	}
+	table, column := strings.TrimSpace(args[0]), ""
+	if len(args) > 1 {
+		column = strings.TrimSpace(args[1])
+	}
+	where := make(map[string]string)
+	if len(args) > 4 {
+		if where, err = parseWhere("unique", args[4:]); err != nil {
+			return nil, err
+		}
+	}
+	if len(args) > 2 {
+		if except := strings.TrimSpace(args[2]); except != "" && except != "NULL" {
+			idColumn := "id"
+			if len(args) > 3 && strings.TrimSpace(args[3]) != "" {
+				idColumn = strings.TrimSpace(args[3])
+			}
+			where[idColumn] = "!" + except
+		}
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		count, err := countPresence(ctx, verifier, table, presenceColumn(ctx, column), where)
+		if err != nil {
+			return false, fmt.Errorf("unable to verify the %s field: %w", ctx.FieldName, err)
+		}
+		if count > 0 {
+			return false, fmt.Errorf("the %s has already been taken", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+var embeddedDatabaseRules = map[string]RuleConstructor{
+	"exists": constructExists,
+	"unique": constructUnique,
+}
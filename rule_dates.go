@@ -0,0 +1,578 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dates:
+// Date
+// Duration
+// Cron
+
+// defaultDateFormats are the layouts accepted by the date rule unless the "date_formats" config sets
+// others. Ambiguous forms such as 01/02/2006 are not accepted.
+var defaultDateFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// iso8601Formats are the ISO 8601 extended calendar date and date-time forms, the time optionally
+// with seconds and a "Z" or "±hh:mm" offset.
+var iso8601Formats = []string{
+	"2006-01-02",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+}
+
+// parseDate parses value with the first matching layout. Fractional seconds are accepted after the
+// seconds of any layout, and values without an offset are in loc.
+func parseDate(value string, layouts []string, loc *time.Location) (time.Time, bool) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parsedDate is the cached result of parseDate, see ValidationContext.parsed.
+type parsedDate struct {
+	time time.Time
+	ok   bool
+}
+
+// date returns the field value parsed with parseDate in the location of the validator, once per field,
+// run and key; key identifies the layouts, see dateCacheKey.
+func (ctx *ValidationContext) date(key string, layouts []string) (time.Time, bool) {
+	return ctx.dateIn(key, layouts, ctx.Location())
+}
+
+// dateIn is like date with dates without an offset in loc; key must identify loc too.
+func (ctx *ValidationContext) dateIn(key string, layouts []string, loc *time.Location) (time.Time, bool) {
+	parsed := ctx.parsed(key, func() interface{} {
+		t, ok := parseDate(ctx.FieldValue, layouts, loc)
+		return parsedDate{t, ok}
+	}).(parsedDate)
+	return parsed.time, parsed.ok
+}
+
+// date is like ValidationContext.date for another field.
+func (o *OtherValue) date(key string, layouts []string, loc *time.Location) (time.Time, bool) {
+	parsed := o.parsed(key, func() interface{} {
+		t, ok := parseDate(o.Value, layouts, loc)
+		return parsedDate{t, ok}
+	}).(parsedDate)
+	return parsed.time, parsed.ok
+}
+
+// dateCacheKey returns the key of the dates parsed with layouts for ValidationContext.date.
+func dateCacheKey(layouts []string) string {
+	return "date:" + strings.Join(layouts, "|")
+}
+
+// dateLayouts returns the layouts of the "date_formats" config, or the default ones.
+func dateLayouts(cfg map[string]interface{}) []string {
+	if formats, ok := cfg["date_formats"].([]string); ok && len(formats) > 0 {
+		return formats
+	}
+	return defaultDateFormats
+}
+
+// InLocation sets the location of dates without an offset, such as "2024-01-31", and the location
+// in which the date comparison rules truncate to days with precision=date. It is UTC by default.
+func (v *Validator) InLocation(loc *time.Location) *Validator {
+	v.location = loc
+	return v
+}
+
+// Location returns the location of dates without an offset, see Validator.InLocation.
+func (ctx *ValidationContext) Location() *time.Location {
+	if ctx.validator != nil && ctx.validator.location != nil {
+		return ctx.validator.location
+	}
+	return time.UTC
+}
+
+// date:iso8601
+// The field under validation must be a date. Without arguments the layouts of the "date_formats" config
+// ([]string of time layouts) are accepted, by default RFC 3339, "2006-01-02T15:04:05",
+// "2006-01-02 15:04:05" and "2006-01-02". With iso8601 only ISO 8601 dates and date-times are accepted,
+// with rfc3339 only RFC 3339 date-times.
+func constructDate(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	layouts, name := dateLayouts(cfg), "date"
+	if len(args) > 1 {
+		return nil, fmt.Errorf("date rule accepts at most 1 argument")
+	}
+	if len(args) == 1 {
+		switch strings.ToLower(strings.TrimSpace(args[0])) {
+		case "iso8601":
+			layouts, name = iso8601Formats, "ISO 8601 date"
+		case "rfc3339":
+			layouts, name = []string{time.RFC3339}, "RFC 3339 date"
+		default:
+			return nil, fmt.Errorf("invalid date format: %s", args[0])
+		}
+	}
+	key := dateCacheKey(layouts)
+	return func(ctx *ValidationContext) (bool, error) {
+		if _, ok := ctx.date(key, layouts); !ok {
+			return false, fmt.Errorf("the %s field must be a valid %s", ctx.FieldName, name)
+		}
+		return true, nil
+	}, nil
+}
+
+// after:date_or_field,precision=date
+// before, after_or_equal, before_or_equal and date_equals take the same arguments.
+// The field under validation must be a date (in the formats of the date rule) compared to the given date,
+// or to the value of another field when the argument is not a date. Dates are compared as instants, so
+// offsets are respected and dates without an offset are in the location of the validator (UTC unless
+// set with Validator.InLocation). With precision=date both dates are converted to that location and
+// only their calendar days are compared.
+func constructDateComparison(rule string, accept func(cmp int) bool, relation string) RuleConstructor {
+	return func(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+		positional, options, err := parseRuleOptions(rule, args, "precision")
+		if err != nil {
+			return nil, err
+		}
+		if len(positional) != 1 {
+			return nil, fmt.Errorf("%s rule requires a date or field argument", rule)
+		}
+		byDay := false
+		if precision, ok := options["precision"]; ok {
+			switch strings.ToLower(precision) {
+			case "date":
+				byDay = true
+			case "time":
+			default:
+				return nil, fmt.Errorf("invalid %s precision: %s", rule, precision)
+			}
+		}
+		layouts, reference := dateLayouts(cfg), strings.TrimSpace(positional[0])
+		key := dateCacheKey(layouts)
+		return func(ctx *ValidationContext) (bool, error) {
+			loc := ctx.Location()
+			value, ok := ctx.date(key, layouts)
+			if !ok {
+				return false, fmt.Errorf("the %s field must be a valid date", ctx.FieldName)
+			}
+			target, ok := parseDate(reference, layouts, loc)
+			name := reference
+			if !ok {
+				if target, ok = ctx.OtherValue(reference).date(key, layouts, loc); !ok {
+					return false, fmt.Errorf("the %s field must be a date %s %s", ctx.FieldName, relation, ctx.Attribute(reference))
+				}
+				name = ctx.Attribute(reference)
+			}
+			if byDay {
+				value, target = truncateToDay(value, loc), truncateToDay(target, loc)
+			}
+			if !accept(value.Compare(target)) {
+				return false, fmt.Errorf("the %s field must be a date %s %s", ctx.FieldName, relation, name)
+			}
+			return true, nil
+		}, nil
+	}
+}
+
+// clockFormats are the layouts of the times of day bounding a daily window of within_window.
+var clockFormats = []string{"15:04", "15:04:05"}
+
+// parseClock returns the time of day written in value as the duration since midnight.
+func parseClock(value string) (time.Duration, bool) {
+	t, ok := parseDate(value, clockFormats, time.UTC)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, true
+}
+
+// within_window:start,end,tz=Europe/Paris
+// The field under validation must be a date between start and end, inclusive. Each bound is a date in
+// the formats of the date rule or another field holding one; a bound field that is missing or empty
+// leaves that side of the window open. When both bounds are times of day ("09:00", "17:30:00"), the
+// window repeats daily and only the time of day of the field is compared, so within_window:09:00,17:00
+// checks business hours and within_window:22:00,06:00 a night crossing midnight. Dates without an
+// offset and times of day are in the tz location, by default the location of the validator.
+func constructWithinWindow(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	positional, options, err := parseRuleOptions("within_window", args, "tz")
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 2 {
+		return nil, fmt.Errorf("within_window rule requires a start and an end argument")
+	}
+	var tz *time.Location
+	if name, ok := options["tz"]; ok {
+		if tz, err = time.LoadLocation(name); err != nil {
+			return nil, fmt.Errorf("invalid within_window tz: %s", name)
+		}
+	}
+	start, end := strings.TrimSpace(positional[0]), strings.TrimSpace(positional[1])
+	startClock, startIsClock := parseClock(start)
+	endClock, endIsClock := parseClock(end)
+	if startIsClock != endIsClock {
+		return nil, fmt.Errorf("within_window bounds must both be times of day or both be dates")
+	}
+	layouts := dateLayouts(cfg)
+	key := dateCacheKey(layouts)
+	if tz != nil {
+		key += "@" + tz.String()
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		loc := tz
+		if loc == nil {
+			loc = ctx.Location()
+		}
+		value, ok := ctx.dateIn(key, layouts, loc)
+		if !ok {
+			return false, fmt.Errorf("the %s field must be a valid date", ctx.FieldName)
+		}
+		if startIsClock {
+			value = value.In(loc)
+			clock := time.Duration(value.Hour())*time.Hour + time.Duration(value.Minute())*time.Minute + time.Duration(value.Second())*time.Second
+			within := clock >= startClock && clock <= endClock
+			if startClock > endClock {
+				within = clock >= startClock || clock <= endClock
+			}
+			if !within {
+				return false, errorWithParams([]string{"start=" + start, "end=" + end}, "the %s field must be between %s and %s", ctx.FieldName, start, end)
+			}
+			return true, nil
+		}
+		name := func(bound string) string {
+			if _, ok := parseDate(bound, layouts, loc); ok {
+				return bound
+			}
+			return ctx.Attribute(bound)
+		}
+		outside := func() (bool, error) {
+			startName, endName := name(start), name(end)
+			return false, errorWithParams([]string{"start=" + startName, "end=" + endName}, "the %s field must be a date between %s and %s", ctx.FieldName, startName, endName)
+		}
+		for i, bound := range []string{start, end} {
+			limit, ok := parseDate(bound, layouts, loc)
+			if !ok {
+				other := ctx.OtherValue(bound)
+				if IsEmptyValue(other.Value) {
+					continue
+				}
+				if limit, ok = other.date(key, layouts, loc); !ok {
+					return outside()
+				}
+			}
+			if i == 0 && value.Before(limit) || i == 1 && value.After(limit) {
+				return outside()
+			}
+		}
+		return true, nil
+	}, nil
+}
+
+// now returns the current time for the rules relative to today, replaced in tests.
+var now = time.Now
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// date_weekday:mon,tue
+// The field under validation must be a date falling on one of the given days of the week, given by their
+// first three letters. The day is taken in the location of the validator.
+func constructDateWeekday(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("date_weekday rule requires at least 1 argument")
+	}
+	var allowed []time.Weekday
+	for _, arg := range args {
+		i := slices.Index(weekdayNames, strings.ToLower(strings.TrimSpace(arg)))
+		if i < 0 {
+			return nil, fmt.Errorf("invalid weekday: %s", arg)
+		}
+		allowed = append(allowed, time.Weekday(i))
+	}
+	layouts := dateLayouts(cfg)
+	key := dateCacheKey(layouts)
+	return func(ctx *ValidationContext) (bool, error) {
+		t, ok := ctx.date(key, layouts)
+		if !ok {
+			return false, fmt.Errorf("the %s field must be a valid date", ctx.FieldName)
+		}
+		if !slices.Contains(allowed, t.In(ctx.Location()).Weekday()) {
+			return false, fmt.Errorf("the %s field must be a %s", ctx.FieldName, strings.Join(args, ", "))
+		}
+		return true, nil
+	}, nil
+}
+
+// date_before_days:30
+// The field under validation must be a date within the last given number of days: not in the future and
+// at most that many calendar days before today, in the location of the validator.
+func constructDateBeforeDays(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("date_before_days rule requires 1 argument")
+	}
+	days, err := strconv.Atoi(strings.TrimSpace(args[0]))
+	if err != nil || days < 0 {
+		return nil, fmt.Errorf("invalid date_before_days argument: %s", args[0])
+	}
+	layouts := dateLayouts(cfg)
+	key := dateCacheKey(layouts)
+	return func(ctx *ValidationContext) (bool, error) {
+		loc := ctx.Location()
+		t, ok := ctx.date(key, layouts)
+		if !ok {
+			return false, fmt.Errorf("the %s field must be a valid date", ctx.FieldName)
+		}
+		current := now()
+		earliest := truncateToDay(current, loc).AddDate(0, 0, -days)
+		if t.After(current) || t.Before(earliest) {
+			return false, fmt.Errorf("the %s field must be a date within the last %d days", ctx.FieldName, days)
+		}
+		return true, nil
+	}, nil
+}
+
+// age:min=18,max=120
+// The field under validation must be a birthdate giving an age in years, as of today in the location of the
+// validator, within the given bounds (inclusive). At least one bound is required.
+func constructAge(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	positional, options, err := parseRuleOptions("age", args, "min", "max")
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) > 0 {
+		return nil, fmt.Errorf("invalid age argument: %s", positional[0])
+	}
+	if len(options) == 0 {
+		return nil, fmt.Errorf("age rule requires a min or max option")
+	}
+	bounds := make(map[string]int, len(options))
+	for key, value := range options {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid age %s: %s", key, value)
+		}
+		bounds[key] = n
+	}
+	minAge, hasMin := bounds["min"]
+	maxAge, hasMax := bounds["max"]
+	if hasMin && hasMax && minAge > maxAge {
+		return nil, fmt.Errorf("minimum age cannot be greater than maximum age")
+	}
+	layouts := dateLayouts(cfg)
+	key := dateCacheKey(layouts)
+	return func(ctx *ValidationContext) (bool, error) {
+		loc := ctx.Location()
+		birth, ok := ctx.date(key, layouts)
+		if !ok {
+			return false, fmt.Errorf("the %s field must be a valid date", ctx.FieldName)
+		}
+		age := ageOn(birth.In(loc), now().In(loc))
+		if hasMin && age < minAge {
+			return false, errorWithParams([]string{"min=" + strconv.Itoa(minAge)}, "the %s field must be a birthdate of someone at least %d years old", ctx.FieldName, minAge)
+		}
+		if hasMax && age > maxAge {
+			return false, errorWithParams([]string{"max=" + strconv.Itoa(maxAge)}, "the %s field must be a birthdate of someone at most %d years old", ctx.FieldName, maxAge)
+		}
+		return true, nil
+	}, nil
+}
+
+// ageOn returns the number of full years between birth and day; people born on February 29 come of
+// age on March 1 in common years.
+func ageOn(birth time.Time, day time.Time) int {
+	age := day.Year() - birth.Year()
+	if day.Month() < birth.Month() || (day.Month() == birth.Month() && day.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
+
+func truncateToDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// duration:min=1s,max=1h
+// The field under validation must be a duration accepted by time.ParseDuration, such as "90s" or "1h30m".
+// The optional min and max options bound the parsed duration (inclusive).
+func constructDuration(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	positional, options, err := parseRuleOptions("duration", args, "min", "max")
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) > 0 {
+		return nil, fmt.Errorf("invalid duration argument: %s", positional[0])
+	}
+	var min, max time.Duration
+	hasMin, hasMax := false, false
+	if value, ok := options["min"]; ok {
+		if min, err = time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("invalid minimum duration: %s", value)
+		}
+		hasMin = true
+	}
+	if value, ok := options["max"]; ok {
+		if max, err = time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("invalid maximum duration: %s", value)
+		}
+		hasMax = true
+	}
+	if hasMin && hasMax && min > max {
+		return nil, fmt.Errorf("minimum duration cannot be greater than maximum duration")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		d, err := time.ParseDuration(ctx.FieldValue)
+		if err != nil {
+			return false, fmt.Errorf("the %s field must be a valid duration", ctx.FieldName)
+		}
+		if hasMin && d < min {
+			return false, fmt.Errorf("the %s field must be at least %s", ctx.FieldName, min)
+		}
+		if hasMax && d > max {
+			return false, fmt.Errorf("the %s field must be at most %s", ctx.FieldName, max)
+		}
+		return true, nil
+	}, nil
+}
+
+type cronField struct {
+	min, max int
+	names    []string // names[i] stands for min+i
+}
+
+var (
+	cronSeconds = cronField{min: 0, max: 59}
+	cronMinutes = cronField{min: 0, max: 59}
+	cronHours   = cronField{min: 0, max: 23}
+	cronDays    = cronField{min: 1, max: 31}
+	cronMonths  = cronField{min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	// 7 is accepted as an alias for Sunday
+	cronWeekdays = cronField{min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+var cronMacros = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+func (f cronField) value(str string) (int, bool) {
+	for i, name := range f.names {
+		if strings.EqualFold(str, name) {
+			return f.min + i, true
+		}
+	}
+	if !isDigits(str) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(str)
+	return n, err == nil && n >= f.min && n <= f.max
+}
+
+// valid reports whether expr is a list of "*", values, ranges ("1-5") and steps ("*/15", "1-30/2").
+func (f cronField) valid(expr string, allowAny bool) bool {
+	if allowAny && expr == "?" {
+		return true
+	}
+	for _, part := range strings.Split(expr, ",") {
+		base, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if !isDigits(step) || err != nil || n < 1 || n > f.max {
+				return false
+			}
+		}
+		if base == "*" {
+			continue
+		}
+		low, high, isRange := strings.Cut(base, "-")
+		from, ok := f.value(low)
+		if !ok {
+			return false
+		}
+		if isRange {
+			to, ok := f.value(high)
+			if !ok || to < from {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isCronExpression(expr string, withSeconds bool) bool {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@") {
+		if every, ok := strings.CutPrefix(expr, "@every "); ok {
+			d, err := time.ParseDuration(strings.TrimSpace(every))
+			return err == nil && d > 0
+		}
+		for _, macro := range cronMacros {
+			if expr == macro {
+				return true
+			}
+		}
+		return false
+	}
+	fields := strings.Fields(expr)
+	layout := []cronField{cronMinutes, cronHours, cronDays, cronMonths, cronWeekdays}
+	if withSeconds {
+		if len(fields) != 6 {
+			return false
+		}
+		layout = append([]cronField{cronSeconds}, layout...)
+	} else if len(fields) != 5 {
+		return false
+	}
+	for i, field := range fields {
+		// "?" is only meaningful for day-of-month and day-of-week
+		allowAny := i >= len(layout)-3 && i != len(layout)-2
+		if !layout[i].valid(field, allowAny) {
+			return false
+		}
+	}
+	return true
+}
+
+// cron:seconds
+// The field under validation must be a 5-field cron expression (minute hour day month weekday) or one of the
+// @yearly, @monthly, @weekly, @daily, @hourly and "@every <duration>" macros. With seconds, a leading seconds
+// field is required (6 fields); with optional_seconds, both forms are accepted.
+func constructCron(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	accept5, accept6 := true, false
+	for _, arg := range args {
+		switch strings.ToLower(strings.TrimSpace(arg)) {
+		case "seconds":
+			accept5, accept6 = false, true
+		case "optional_seconds":
+			accept5, accept6 = true, true
+		default:
+			return nil, fmt.Errorf("invalid cron modifier: %s", arg)
+		}
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		if (accept5 && isCronExpression(ctx.FieldValue, false)) || (accept6 && isCronExpression(ctx.FieldValue, true)) {
+			return true, nil
+		}
+		return false, fmt.Errorf("the %s field must be a valid cron expression", ctx.FieldName)
+	}, nil
+}
+
+var embeddedDateRules = map[string]RuleConstructor{
+	"date":             constructDate,
+	"after":            constructDateComparison("after", func(cmp int) bool { return cmp > 0 }, "after"),
+	"after_or_equal":   constructDateComparison("after_or_equal", func(cmp int) bool { return cmp >= 0 }, "after or equal to"),
+	"before":           constructDateComparison("before", func(cmp int) bool { return cmp < 0 }, "before"),
+	"before_or_equal":  constructDateComparison("before_or_equal", func(cmp int) bool { return cmp <= 0 }, "before or equal to"),
+	"date_equals":      constructDateComparison("date_equals", func(cmp int) bool { return cmp == 0 }, "equal to"),
+	"date_weekday":     constructDateWeekday,
+	"date_before_days": constructDateBeforeDays,
+	"age":              constructAge,
+	"within_window":    constructWithinWindow,
+	"duration":         constructDuration,
+	"cron":             constructCron,
+}
@@ -0,0 +1,369 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Formats:
+// Credit Card
+// Hash
+// Bcrypt Hash
+// MIME Type String
+// Format (registered formats)
+// EAN / GTIN / ISBN
+// CSS Length
+
+// luhnValid reports whether the digit string passes the Luhn checksum.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+type cardBrand struct {
+	lengths []int
+	// prefix ranges, inclusive, compared against the leading digits of the same width
+	ranges [][2]int
+}
+
+var cardBrands = map[string]cardBrand{
+	"visa":       {lengths: []int{13, 16, 19}, ranges: [][2]int{{4, 4}}},
+	"mastercard": {lengths: []int{16}, ranges: [][2]int{{51, 55}, {2221, 2720}}},
+	"amex":       {lengths: []int{15}, ranges: [][2]int{{34, 34}, {37, 37}}},
+	"discover":   {lengths: []int{16, 17, 18, 19}, ranges: [][2]int{{6011, 6011}, {644, 649}, {65, 65}, {622126, 622925}}},
+	"diners":     {lengths: []int{14, 15, 16, 17, 18, 19}, ranges: [][2]int{{300, 305}, {36, 36}, {38, 39}}},
+	"jcb":        {lengths: []int{16, 17, 18, 19}, ranges: [][2]int{{3528, 3589}}},
+	"unionpay":   {lengths: []int{16, 17, 18, 19}, ranges: [][2]int{{62, 62}}},
+	"maestro":    {lengths: []int{12, 13, 14, 15, 16, 17, 18, 19}, ranges: [][2]int{{50, 50}, {56, 69}}},
+}
+
+func (b cardBrand) matches(digits string) bool {
+	lengthOK := false
+	for _, l := range b.lengths {
+		if len(digits) == l {
+			lengthOK = true
+			break
+		}
+	}
+	if !lengthOK {
+		return false
+	}
+	for _, r := range b.ranges {
+		width := len(strconv.Itoa(r[0]))
+		prefix, _ := strconv.Atoi(digits[:width])
+		if prefix >= r[0] && prefix <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// credit_card:visa,mastercard,...
+// The field under validation must be a card number passing the Luhn checksum. Spaces and dashes are ignored.
+// When brands are given, the number must belong to one of them. Messages never include the number itself.
+func constructCreditCard(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	brands := make([]string, 0, len(args))
+	for _, arg := range args {
+		brand := strings.ToLower(strings.TrimSpace(arg))
+		if _, ok := cardBrands[brand]; !ok {
+			return nil, fmt.Errorf("unknown credit card brand: %s", arg)
+		}
+		brands = append(brands, brand)
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		digits := strings.NewReplacer(" ", "", "-", "").Replace(ctx.FieldValue)
+		if len(digits) < 12 || len(digits) > 19 || !isDigits(digits) || !luhnValid(digits) {
+			return false, fmt.Errorf("the %s field must be a valid credit card number", ctx.FieldName)
+		}
+		if len(brands) == 0 {
+			return true, nil
+		}
+		for _, brand := range brands {
+			if cardBrands[brand].matches(digits) {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("the %s field must be a card of type: %s", ctx.FieldName, strings.Join(brands, ", "))
+	}, nil
+}
+
+// hex digest lengths by algorithm
+var hashLengths = map[string]int{
+	"md5":    32,
+	"sha1":   40,
+	"sha224": 56,
+	"sha256": 64,
+	"sha384": 96,
+	"sha512": 128,
+}
+
+func isHex(str string) bool {
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') && !(c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return str != ""
+}
+
+// hash:md5,sha1,sha224,sha256,sha384,sha512
+// The field under validation must be a hexadecimal digest of one of the given algorithms.
+func constructHash(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("hash rule requires at least 1 argument")
+	}
+	algorithms := make([]string, 0, len(args))
+	for _, arg := range args {
+		algorithm := strings.ToLower(strings.TrimSpace(arg))
+		if _, ok := hashLengths[algorithm]; !ok {
+			return nil, fmt.Errorf("unknown hash algorithm: %s", arg)
+		}
+		algorithms = append(algorithms, algorithm)
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		if isHex(ctx.FieldValue) {
+			for _, algorithm := range algorithms {
+				if len(ctx.FieldValue) == hashLengths[algorithm] {
+					return true, nil
+				}
+			}
+		}
+		return false, fmt.Errorf("the %s field must be a valid %s hash", ctx.FieldName, strings.Join(algorithms, " or "))
+	}, nil
+}
+
+var bcryptRegexp = regexp.MustCompile(`^\$2[abxy]?\$(0[4-9]|[12]\d|3[01])\$[./A-Za-z0-9]{53}$`)
+
+// bcrypt_hash
+// The field under validation must be a bcrypt hash in modular crypt format ($2y$10$...) with a cost between 4 and 31.
+func constructBcryptHash(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+	return func(ctx *ValidationContext) (bool, error) {
+		if !bcryptRegexp.MatchString(ctx.FieldValue) {
+			return false, fmt.Errorf("the %s field must be a valid bcrypt hash", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+// RFC 6838 restricted-name: an alphanumeric followed by up to 126 of the allowed characters
+const mimeRestrictedName = `[a-zA-Z0-9][a-zA-Z0-9!#$&^_.+-]{0,126}`
+
+var mimeTypeRegexp = regexp.MustCompile(`^` + mimeRestrictedName + `/` + mimeRestrictedName + `$`)
+
+// mime_type_string:image,application/json,...
+// The field under validation must be a media type of the form type/subtype (RFC 6838), such as "image/png".
+// When arguments are given, the value must be in one of the listed top-level types ("image") or be one of the
+// listed full types ("application/json"). Comparison is case-insensitive. Unlike content-based checks, only
+// the string form is validated.
+func constructMimeTypeString(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	allowed := make([]string, 0, len(args))
+	for _, arg := range args {
+		arg = strings.ToLower(strings.TrimSpace(strings.TrimSuffix(arg, "/*")))
+		if arg == "" {
+			return nil, fmt.Errorf("invalid mime_type_string argument")
+		}
+		allowed = append(allowed, arg)
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		if !mimeTypeRegexp.MatchString(ctx.FieldValue) {
+			return false, fmt.Errorf("the %s field must be a valid MIME type", ctx.FieldName)
+		}
+		if len(allowed) == 0 {
+			return true, nil
+		}
+		value := strings.ToLower(ctx.FieldValue)
+		tree, _, _ := strings.Cut(value, "/")
+		for _, a := range allowed {
+			if a == value || a == tree {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("the %s field must be a MIME type of: %s", ctx.FieldName, strings.Join(allowed, ", "))
+	}, nil
+}
+
+// gtinValid reports whether the digit string ends with the GS1 check digit of the digits before it,
+// weighted 3 and 1 alternately from the right as EAN, UPC, GTIN and ISBN-13 numbers are.
+func gtinValid(digits string) bool {
+	sum := 0
+	for i := len(digits) - 2; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-2-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10-sum%10)%10 == int(digits[len(digits)-1]-'0')
+}
+
+// isbnDigits returns value without the hyphens and spaces ISBNs are usually printed with.
+func isbnDigits(value string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(value)
+}
+
+// isbn10Valid reports whether value is an ISBN-10: 9 digits and a check digit, 0-9 or X, making the
+// sum of the digits weighted 10 down to 1 a multiple of 11.
+func isbn10Valid(value string) bool {
+	if len(value) != 10 || !isDigits(value[:9]) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(value[i]-'0') * (10 - i)
+	}
+	switch check := value[9]; {
+	case check == 'X' || check == 'x':
+		sum += 10
+	case check >= '0' && check <= '9':
+		sum += int(check - '0')
+	default:
+		return false
+	}
+	return sum%11 == 0
+}
+
+// barcodeRule returns a rule checking that the field, normalized by clean when it is not nil, is
+// accepted by valid.
+func barcodeRule(name string, clean func(string) string, valid func(string) bool) RuleConstructor {
+	return func(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("%s rule takes no arguments", name)
+		}
+		return func(ctx *ValidationContext) (bool, error) {
+			value := ctx.FieldValue
+			if clean != nil {
+				value = clean(value)
+			}
+			if !valid(value) {
+				return false, fmt.Errorf("the %s field must be a valid %s", ctx.FieldName, name)
+			}
+			return true, nil
+		}, nil
+	}
+}
+
+// ean13, ean8
+// The field under validation must be an EAN-13 or EAN-8 barcode number: 13 or 8 digits ending with a
+// valid check digit.
+// gtin
+// The field under validation must be a GTIN-8, GTIN-12 (UPC-A), GTIN-13 or GTIN-14 with a valid check
+// digit.
+// isbn10, isbn13
+// The field under validation must be an ISBN-10 (check digit 0-9 or X) or an ISBN-13 (978 or 979
+// prefix) with a valid check digit. Hyphens and spaces are ignored: "978-3-16-148410-0".
+var (
+	constructEAN13 = barcodeRule("ean13", nil, func(value string) bool {
+		return len(value) == 13 && isDigits(value) && gtinValid(value)
+	})
+	constructEAN8 = barcodeRule("ean8", nil, func(value string) bool {
+		return len(value) == 8 && isDigits(value) && gtinValid(value)
+	})
+	constructGTIN = barcodeRule("gtin", nil, func(value string) bool {
+		switch len(value) {
+		case 8, 12, 13, 14:
+			return isDigits(value) && gtinValid(value)
+		}
+		return false
+	})
+	constructISBN10 = barcodeRule("isbn10", isbnDigits, isbn10Valid)
+	constructISBN13 = barcodeRule("isbn13", isbnDigits, func(value string) bool {
+		return len(value) == 13 && isDigits(value) && (strings.HasPrefix(value, "978") || strings.HasPrefix(value, "979")) && gtinValid(value)
+	})
+)
+
+// cssLengthUnits are the CSS length units, absolute, font relative and viewport relative, and the
+// percentage most length properties also accept.
+var cssLengthUnits = []string{
+	"px", "cm", "mm", "q", "in", "pt", "pc",
+	"em", "rem", "ex", "rex", "ch", "rch", "cap", "ic", "lh", "rlh",
+	"vw", "vh", "vi", "vb", "vmin", "vmax", "svw", "svh", "lvw", "lvh", "dvw", "dvh",
+	"%",
+}
+
+var cssLengthRegexp = regexp.MustCompile(`^([+-]?(?:\d+(?:\.\d+)?|\.\d+))([a-zA-Z]+|%)?$`)
+
+// css_length
+// css_length:px,rem,%,...
+// The field under validation must be a CSS length such as "12px", "1.5rem", "-0.5em" or "50%", with
+// one of the given units when some are. Units are case-insensitive; 0 may be written without one,
+// like in CSS. Keywords such as auto and calc() expressions fail.
+func constructCSSLength(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	units := cssLengthUnits
+	if len(args) > 0 {
+		units = make([]string, 0, len(args))
+		for _, arg := range args {
+			unit := strings.ToLower(strings.TrimSpace(arg))
+			if !slices.Contains(cssLengthUnits, unit) {
+				return nil, fmt.Errorf("invalid css_length unit: %s", arg)
+			}
+			units = append(units, unit)
+		}
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		parts := cssLengthRegexp.FindStringSubmatch(ctx.FieldValue)
+		if parts != nil {
+			if unit := strings.ToLower(parts[2]); unit == "" {
+				if num, _ := strconv.ParseFloat(parts[1], 64); num == 0 {
+					return true, nil
+				}
+			} else if slices.Contains(units, unit) {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("the %s field must be a valid CSS length (%s)", ctx.FieldName, strings.Join(units, ", "))
+	}, nil
+}
+
+// format:name
+// The field under validation must be in the format registered under name in the FormatRegistry of the
+// factory (see Factory.RegisterFormat), e.g. format:cpf_br. Unknown names fail when parsing.
+func constructFormat(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) != 1 || strings.TrimSpace(args[0]) == "" {
+		return nil, fmt.Errorf("format rule requires a format name")
+	}
+	name := strings.TrimSpace(args[0])
+	registry, ok := cfg["format_registry"].(*FormatRegistry)
+	if !ok {
+		registry = defaultFormatRegistry
+	}
+	format, ok := registry.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("format rule has unknown format %q", name)
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		if !format(ctx.FieldValue) {
+			return false, errorWithParams([]string{"format=" + name}, "the %s field must be a valid %s", ctx.FieldName, name)
+		}
+		return true, nil
+	}, nil
+}
+
+var embeddedFormatRules = map[string]RuleConstructor{
+	"format":           constructFormat,
+	"css_length":       constructCSSLength,
+	"ean13":            constructEAN13,
+	"ean8":             constructEAN8,
+	"gtin":             constructGTIN,
+	"isbn10":           constructISBN10,
+	"isbn13":           constructISBN13,
+	"credit_card":      constructCreditCard,
+	"hash":             constructHash,
+	"bcrypt_hash":      constructBcryptHash,
+	"mime_type_string": constructMimeTypeString,
+}
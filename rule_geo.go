@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Geo:
+// Latitude
+// Longitude
+// Geo Point
+
+func parseCoordinate(str string, limit float64) (float64, bool) {
+	str = strings.TrimSpace(str)
+	if !isNumeric(strings.TrimPrefix(str, "+")) {
+		return 0, false
+	}
+	num, err := strconv.ParseFloat(str, 64)
+	if err != nil || num < -limit || num > limit {
+		return 0, false
+	}
+	return num, true
+}
+
+func isLatitude(str string) bool {
+	_, ok := parseCoordinate(str, 90)
+	return ok
+}
+
+func isLongitude(str string) bool {
+	_, ok := parseCoordinate(str, 180)
+	return ok
+}
+
+// parseGeoPoint accepts either a "lat,lng" string or a JSON object with lat/lng
+// (or latitude/longitude, lon) members holding numbers or numeric strings.
+func parseGeoPoint(str string) (lat string, lng string, ok bool) {
+	str = strings.TrimSpace(str)
+	if !strings.HasPrefix(str, "{") {
+		lat, lng, ok = strings.Cut(str, ",")
+		return lat, lng, ok
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(str), &object); err != nil {
+		return "", "", false
+	}
+	member := func(keys ...string) (string, bool) {
+		for _, key := range keys {
+			raw, exists := object[key]
+			if !exists {
+				continue
+			}
+			var s string
+			if json.Unmarshal(raw, &s) == nil {
+				return s, true
+			}
+			var n json.Number
+			if json.Unmarshal(raw, &n) == nil {
+				return n.String(), true
+			}
+			return "", false
+		}
+		return "", false
+	}
+	lat, latOK := member("lat", "latitude")
+	lng, lngOK := member("lng", "lon", "longitude")
+	return lat, lng, latOK && lngOK
+}
+
+// latitude
+// The field under validation must be a decimal latitude between -90 and 90.
+func constructLatitude(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+	return func(ctx *ValidationContext) (bool, error) {
+		if !isLatitude(ctx.FieldValue) {
+			return false, fmt.Errorf("the %s field must be a valid latitude", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+// longitude
+// The field under validation must be a decimal longitude between -180 and 180.
+func constructLongitude(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+	return func(ctx *ValidationContext) (bool, error) {
+		if !isLongitude(ctx.FieldValue) {
+			return false, fmt.Errorf("the %s field must be a valid longitude", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+// geo_point
+// The field under validation must be a "lat,lng" pair or a JSON object such as {"lat": 52.52, "lng": 13.40}
+// whose coordinates are within valid ranges.
+func constructGeoPoint(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+	return func(ctx *ValidationContext) (bool, error) {
+		lat, lng, ok := parseGeoPoint(ctx.FieldValue)
+		if !ok {
+			return false, fmt.Errorf("the %s field must be a valid geo point", ctx.FieldName)
+		}
+		if !isLatitude(lat) {
+			return false, fmt.Errorf("the %s field must have a latitude between -90 and 90", ctx.FieldName)
+		}
+		if !isLongitude(lng) {
+			return false, fmt.Errorf("the %s field must have a longitude between -180 and 180", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+var embeddedGeoRules = map[string]RuleConstructor{
+	"latitude":  constructLatitude,
+	"longitude": constructLongitude,
+	"geo_point": constructGeoPoint,
+}
@@ -0,0 +1,340 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Locale:
+// Country Code
+// Currency Code
+// Language Code
+// Postal Code
+
+// ISO 3166-1 alpha-2 codes, each followed by its alpha-3 counterpart.
+const iso3166Table = `
+AD AND AE ARE AF AFG AG ATG AI AIA AL ALB AM ARM AO AGO AQ ATA AR ARG AS ASM AT AUT AU AUS AW ABW AX ALA AZ AZE
+BA BIH BB BRB BD BGD BE BEL BF BFA BG BGR BH BHR BI BDI BJ BEN BL BLM BM BMU BN BRN BO BOL BQ BES BR BRA BS BHS
+BT BTN BV BVT BW BWA BY BLR BZ BLZ CA CAN CC CCK CD COD CF CAF CG COG CH CHE CI CIV CK COK CL CHL CM CMR CN CHN
+CO COL CR CRI CU CUB CV CPV CW CUW CX CXR CY CYP CZ CZE DE DEU DJ DJI DK DNK DM DMA DO DOM DZ DZA EC ECU EE EST
+EG EGY EH ESH ER ERI ES ESP ET ETH FI FIN FJ FJI FK FLK FM FSM FO FRO FR FRA GA GAB GB GBR GD GRD GE GEO GF GUF
+GG GGY GH GHA GI GIB GL GRL GM GMB GN GIN GP GLP GQ GNQ GR GRC GS SGS GT GTM GU GUM GW GNB GY GUY HK HKG HM HMD
+HN HND HR HRV HT HTI HU HUN ID IDN IE IRL IL ISR IM IMN IN IND IO IOT IQ IRQ IR IRN IS ISL IT ITA JE JEY JM JAM
+JO JOR JP JPN KE KEN KG KGZ KH KHM KI KIR KM COM KN KNA KP PRK KR KOR KW KWT KY CYM KZ KAZ LA LAO LB LBN LC LCA
+LI LIE LK LKA LR LBR LS LSO LT LTU LU LUX LV LVA LY LBY MA MAR MC MCO MD MDA ME MNE MF MAF MG MDG MH MHL MK MKD
+ML MLI MM MMR MN MNG MO MAC MP MNP MQ MTQ MR MRT MS MSR MT MLT MU MUS MV MDV MW MWI MX MEX MY MYS MZ MOZ NA NAM
+NC NCL NE NER NF NFK NG NGA NI NIC NL NLD NO NOR NP NPL NR NRU NU NIU NZ NZL OM OMN PA PAN PE PER PF PYF PG PNG
+PH PHL PK PAK PL POL PM SPM PN PCN PR PRI PS PSE PT PRT PW PLW PY PRY QA QAT RE REU RO ROU RS SRB RU RUS RW RWA
+SA SAU SB SLB SC SYC SD SDN SE SWE SG SGP SH SHN SI SVN SJ SJM SK SVK SL SLE SM SMR SN SEN SO SOM SR SUR SS SSD
+ST STP SV SLV SX SXM SY SYR SZ SWZ TC TCA TD TCD TF ATF TG TGO TH THA TJ TJK TK TKL TL TLS TM TKM TN TUN TO TON
+TR TUR TT TTO TV TUV TW TWN TZ TZA UA UKR UG UGA UM UMI US USA UY URY UZ UZB VA VAT VC VCT VE VEN VG VGB VI VIR
+VN VNM VU VUT WF WLF WS WSM YE YEM YT MYT ZA ZAF ZM ZMB ZW ZWE`
+
+// ISO 4217 active currency and fund codes.
+const iso4217Table = `
+AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV BRL BSD BTN BWP BYN BZD CAD CDF
+CHE CHF CHW CLF CLP CNY COP COU CRC CUC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD
+GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR
+LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK
+PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SLL SOS SRD SSP STN SVC SYP SZL THB TJS TMT
+TND TOP TRY TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS VED VES VND VUV WST XAF XAG XAU XBA XBB XBC XBD XCD XCG
+XDR XOF XPD XPF XPT XSU XTS XUA XXX YER ZAR ZMW ZWG ZWL`
+
+// ISO 639-1 two-letter language codes.
+const iso639Table = `
+aa ab ae af ak am an ar as av ay az ba be bg bi bm bn bo br bs ca ce ch co cr cs cu cv cy da de dv dz ee el en
+eo es et eu fa ff fi fj fo fr fy ga gd gl gn gu gv ha he hi ho hr ht hu hy hz ia id ie ig ii ik io is it iu ja
+jv ka kg ki kj kk kl km kn ko kr ks ku kv kw ky la lb lg li ln lo lt lu lv mg mh mi mk ml mn mr ms mt my na nb
+nd ne ng nl nn no nr nv ny oc oj om or os pa pi pl ps pt qu rm rn ro ru rw sa sc sd se sg si sk sl sm sn so sq
+sr ss st su sv sw ta te tg th ti tk tl tn to tr ts tt tw ty ug uk ur uz ve vi vo wa wo xh yi yo za zh zu`
+
+var (
+	countryAlpha2 = map[string]struct{}{}
+	countryAlpha3 = map[string]struct{}{}
+	currencyCodes = map[string]struct{}{}
+	languageCodes = map[string]struct{}{}
+)
+
+func init() {
+	countries := strings.Fields(iso3166Table)
+	for i := 0; i+1 < len(countries); i += 2 {
+		countryAlpha2[countries[i]] = struct{}{}
+		countryAlpha3[countries[i+1]] = struct{}{}
+	}
+	for _, code := range strings.Fields(iso4217Table) {
+		currencyCodes[code] = struct{}{}
+	}
+	for _, code := range strings.Fields(iso639Table) {
+		languageCodes[code] = struct{}{}
+	}
+}
+
+func inCodeTable(table map[string]struct{}, code string) bool {
+	_, ok := table[code]
+	return ok
+}
+
+func isAlpha(str string) bool {
+	for i := 0; i < len(str); i++ {
+		c := str[i] | 0x20
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return str != ""
+}
+
+func isAlphaNum(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if !isAlpha(str[i:i+1]) && (str[i] < '0' || str[i] > '9') {
+			return false
+		}
+	}
+	return str != ""
+}
+
+// isLanguageTag reports whether tag is a well-formed BCP 47 language tag
+// (language[-extlang][-script][-region][-variant...][-extension...][-x-private]).
+// Two-letter primary subtags and regions are checked against the ISO tables;
+// other subtags are checked for well-formedness only.
+func isLanguageTag(tag string) bool {
+	subtags := strings.Split(tag, "-")
+	primary := subtags[0]
+	switch {
+	case strings.EqualFold(primary, "x"):
+		return len(subtags) > 1 && isPrivateUse(subtags[1:])
+	case len(primary) == 2:
+		if !inCodeTable(languageCodes, strings.ToLower(primary)) {
+			return false
+		}
+	case (len(primary) == 3 || (len(primary) >= 5 && len(primary) <= 8)) && isAlpha(primary):
+	default:
+		return false
+	}
+	i := 1
+	// up to three extended language subtags
+	for n := 0; n < 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlpha(subtags[i]); n++ {
+		i++
+	}
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+		i++
+	}
+	if i < len(subtags) {
+		region := subtags[i]
+		if len(region) == 2 && isAlpha(region) {
+			if !inCodeTable(countryAlpha2, strings.ToUpper(region)) {
+				return false
+			}
+			i++
+		} else if len(region) == 3 && isDigits(region) {
+			i++
+		}
+	}
+	for ; i < len(subtags); i++ {
+		subtag := subtags[i]
+		switch {
+		case len(subtag) >= 5 && len(subtag) <= 8 && isAlphaNum(subtag):
+		case len(subtag) == 4 && subtag[0] >= '0' && subtag[0] <= '9' && isAlphaNum(subtag):
+		case len(subtag) == 1 && strings.EqualFold(subtag, "x"):
+			return isPrivateUse(subtags[i+1:])
+		case len(subtag) == 1 && isAlphaNum(subtag):
+			// extension singleton followed by at least one 2-8 character subtag
+			n := 0
+			for i+1 < len(subtags) && len(subtags[i+1]) >= 2 && len(subtags[i+1]) <= 8 && isAlphaNum(subtags[i+1]) {
+				i++
+				n++
+			}
+			if n == 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isPrivateUse(subtags []string) bool {
+	for _, subtag := range subtags {
+		if len(subtag) < 1 || len(subtag) > 8 || !isAlphaNum(subtag) {
+			return false
+		}
+	}
+	return len(subtags) > 0
+}
+
+// country_code:alpha2,alpha3
+// The field under validation must be an ISO 3166-1 country code. Only alpha-2 codes (US) are accepted by
+// default; alpha3 accepts alpha-3 codes (USA) instead, and both may be listed. Codes are case-insensitive.
+func constructCountryCode(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	alpha2, alpha3 := len(args) == 0, false
+	for _, arg := range args {
+		switch strings.ToLower(strings.TrimSpace(arg)) {
+		case "alpha2":
+			alpha2 = true
+		case "alpha3":
+			alpha3 = true
+		default:
+			return nil, fmt.Errorf("invalid country_code standard: %s", arg)
+		}
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		code := strings.ToUpper(ctx.FieldValue)
+		if (alpha2 && inCodeTable(countryAlpha2, code)) || (alpha3 && inCodeTable(countryAlpha3, code)) {
+			return true, nil
+		}
+		return false, fmt.Errorf("the %s field must be a valid country code", ctx.FieldName)
+	}, nil
+}
+
+// currency_code
+// The field under validation must be an ISO 4217 currency code such as EUR. Codes are case-insensitive.
+func constructCurrencyCode(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+	return func(ctx *ValidationContext) (bool, error) {
+		if !inCodeTable(currencyCodes, strings.ToUpper(ctx.FieldValue)) {
+			return false, fmt.Errorf("the %s field must be a valid currency code", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+// language_code:bcp47,iso639_1
+// The field under validation must be a BCP 47 language tag such as en, en-US or zh-Hant-TW (the default).
+// With iso639_1, only two-letter ISO 639-1 codes are accepted.
+func constructLanguageCode(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	bcp47, iso639 := len(args) == 0, false
+	for _, arg := range args {
+		switch strings.ToLower(strings.TrimSpace(arg)) {
+		case "bcp47":
+			bcp47 = true
+		case "iso639_1":
+			iso639 = true
+		default:
+			return nil, fmt.Errorf("invalid language_code standard: %s", arg)
+		}
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		if (iso639 && inCodeTable(languageCodes, strings.ToLower(ctx.FieldValue))) || (bcp47 && isLanguageTag(ctx.FieldValue)) {
+			return true, nil
+		}
+		return false, fmt.Errorf("the %s field must be a valid language code", ctx.FieldName)
+	}, nil
+}
+
+// Postal code formats keyed by ISO 3166-1 alpha-2 country code. Matching is case-insensitive.
+var postalCodePatterns = map[string]string{
+	"AR": `[A-HJ-NP-Z]?\d{4}([A-Z]{3})?`,
+	"AT": `\d{4}`,
+	"AU": `\d{4}`,
+	"BE": `\d{4}`,
+	"BR": `\d{5}-?\d{3}`,
+	"CA": `[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] ?\d[ABCEGHJ-NPRSTV-Z]\d`,
+	"CH": `\d{4}`,
+	"CN": `\d{6}`,
+	"CZ": `\d{3} ?\d{2}`,
+	"DE": `\d{5}`,
+	"DK": `\d{4}`,
+	"EE": `\d{5}`,
+	"ES": `(0[1-9]|[1-4]\d|5[0-2])\d{3}`,
+	"FI": `\d{5}`,
+	"FR": `\d{5}`,
+	"GB": `GIR ?0AA|[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}`,
+	"GR": `\d{3} ?\d{2}`,
+	"HR": `\d{5}`,
+	"HU": `\d{4}`,
+	"IE": `([AC-FHKNPRTV-Y]\d{2}|D6W) ?[0-9AC-FHKNPRTV-Y]{4}`,
+	"IL": `\d{5}(\d{2})?`,
+	"IN": `[1-9]\d{2} ?\d{3}`,
+	"IS": `\d{3}`,
+	"IT": `\d{5}`,
+	"JP": `\d{3}-?\d{4}`,
+	"KR": `\d{5}`,
+	"LT": `(LT-)?\d{5}`,
+	"LU": `(L-)?\d{4}`,
+	"LV": `(LV-)?\d{4}`,
+	"MX": `\d{5}`,
+	"NL": `\d{4} ?[A-Z]{2}`,
+	"NO": `\d{4}`,
+	"NZ": `\d{4}`,
+	"PL": `\d{2}-\d{3}`,
+	"PT": `\d{4}-\d{3}`,
+	"RO": `\d{6}`,
+	"RU": `\d{6}`,
+	"SE": `\d{3} ?\d{2}`,
+	"SG": `\d{6}`,
+	"SI": `\d{4}`,
+	"SK": `\d{3} ?\d{2}`,
+	"TR": `\d{5}`,
+	"TW": `\d{3}(\d{2,3})?`,
+	"UA": `\d{5}`,
+	"US": `\d{5}(-\d{4})?`,
+	"ZA": `\d{4}`,
+}
+
+var postalCodeRegexps = map[string]*regexp.Regexp{}
+
+func init() {
+	for country, pattern := range postalCodePatterns {
+		postalCodeRegexps[country] = regexp.MustCompile(`^(?i)(` + pattern + `)$`)
+	}
+}
+
+func isPostalCode(country string, code string) bool {
+	re, ok := postalCodeRegexps[strings.ToUpper(strings.TrimSpace(country))]
+	return ok && re.MatchString(code)
+}
+
+// postal_code:US,CA,...
+// The field under validation must be a postal code valid in at least one of the given countries.
+func constructPostalCode(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("postal_code rule requires at least 1 argument")
+	}
+	countries := make([]string, 0, len(args))
+	for _, arg := range args {
+		country := strings.ToUpper(strings.TrimSpace(arg))
+		if _, ok := postalCodeRegexps[country]; !ok {
+			return nil, fmt.Errorf("unsupported postal_code country: %s", arg)
+		}
+		countries = append(countries, country)
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		for _, country := range countries {
+			if isPostalCode(country, ctx.FieldValue) {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("the %s field must be a valid postal code for %s", ctx.FieldName, strings.Join(countries, ", "))
+	}, nil
+}
+
+// postal_code_with:country_field
+// The field under validation must be a postal code valid in the country (ISO 3166-1 alpha-2) held by country_field.
+func constructPostalCodeWith(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("postal_code_with rule requires 1 argument")
+	}
+	countryField := args[0]
+	return func(ctx *ValidationContext) (bool, error) {
+		country, ok := ctx.Lookup(countryField)
+		if !ok || strings.TrimSpace(country) == "" {
+			return false, fmt.Errorf("the %s field is not present", ctx.Attribute(countryField))
+		}
+		if _, supported := postalCodeRegexps[strings.ToUpper(strings.TrimSpace(country))]; !supported {
+			return false, fmt.Errorf("the %s field must be a valid postal code for %s", ctx.FieldName, country)
+		}
+		if !isPostalCode(country, ctx.FieldValue) {
+			return false, fmt.Errorf("the %s field must be a valid postal code for %s", ctx.FieldName, strings.ToUpper(country))
+		}
+		return true, nil
+	}, nil
+}
+
+var embeddedLocaleRules = map[string]RuleConstructor{
+	"country_code":     constructCountryCode,
+	"currency_code":    constructCurrencyCode,
+	"language_code":    constructLanguageCode,
+	"postal_code":      constructPostalCode,
+	"postal_code_with": constructPostalCodeWith,
+}
@@ -2,25 +2,113 @@ package validation
 
 import (
 	"fmt"
+	"math"
 	"regexp"
+	"strconv"
+	"strings"
 )
 
 const numericRegex = `^-?\d+(\.\d+)?$`
 
-var numbeicRegexp *regexp.Regexp
+// compiled once at package init: lazily assigning it from concurrent validations raced
+var numericRegexp = regexp.MustCompile(numericRegex)
 
 func isNumeric(str string) bool {
-	if numbeicRegexp == nil {
-		numbeicRegexp = regexp.MustCompile(numericRegex)
+	return numericRegexp.MatchString(str)
+}
+
+// TrimNumbers makes validators parsed afterwards ignore the whitespace around numbers, so that " 42 "
+// passes integer and numeric and counts as 42 for the size rules, as in Laravel where a middleware
+// trims the input. By default such values are not numbers. The value itself is left as written; the
+// trim sanitizer removes the whitespace from the output of Validated.
+func (f *Factory) TrimNumbers() {
+	f.trimNumbers = true
+}
+
+// numberString returns str as the numeric rules read it, trimmed when the validator trims numbers.
+// All the numeric parsing of values goes through it so that the policy applies uniformly.
+func (v *Validator) numberString(str string) string {
+	if v != nil && v.trimNumbers {
+		return strings.TrimSpace(str)
+	}
+	return str
+}
+
+// numberValue returns the value of the field as the numeric rules read it, see numberString.
+func (ctx *ValidationContext) numberValue() string {
+	return ctx.validator.numberString(ctx.FieldValue)
+}
+
+// parseNumber returns str parsed as a number, and whether it is numeric.
+func parseNumber(str string) (float64, bool) {
+	if !isNumeric(str) {
+		return 0, false
+	}
+	num, err := strconv.ParseFloat(str, 64)
+	return num, err == nil
+}
+
+// parseStrictArg reports whether the only argument of rule is "strict".
+func parseStrictArg(rule string, args []string) (bool, error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+	if len(args) == 1 && args[0] == "strict" {
+		return true, nil
 	}
-	return numbeicRegexp.MatchString(str)
+	return false, fmt.Errorf("invalid %s argument: %s", rule, strings.Join(args, ","))
 }
 
+// isCanonicalNumber reports whether a numeric string is written the way a number would be formatted:
+// no superfluous leading zeros ("007") and no negative zero ("-0", "-0.0").
+func isCanonicalNumber(str string) bool {
+	unsigned := strings.TrimPrefix(str, "-")
+	integerPart, _, _ := strings.Cut(unsigned, ".")
+	if len(integerPart) > 1 && integerPart[0] == '0' {
+		return false
+	}
+	if unsigned != str && strings.Trim(unsigned, "0.") == "" {
+		return false
+	}
+	return true
+}
+
+// strictNumberError returns the error of numeric:strict and integer:strict for value, or nil. Like in
+// Laravel, strict mode rejects numbers given as strings: with typed input (ValidateData,
+// ValidateStruct) a value of TypeString fails. Input given to Validate is made of strings only, so
+// there strict mode rejects the representations a formatted number never has, such as "007" or "-0".
+func strictNumberError(ctx *ValidationContext, value string, kind string) error {
+	if ctx.types != nil {
+		if ctx.Type == TypeString {
+			return fmt.Errorf("%s must be %s, not a string", ctx.FieldName, kind)
+		}
+		return nil
+	}
+	if !isCanonicalNumber(value) {
+		return fmt.Errorf("%s must be %s without leading zeros or a negative zero", ctx.FieldName, kind)
+	}
+	return nil
+}
+
+// numeric
+// numeric:strict
+// The field under validation must be numeric. Strict mode rejects numbers given as strings, see
+// strictNumberError.
 func constructNumericRule(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	strict, err := parseStrictArg("numeric", args)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx *ValidationContext) (bool, error) {
-		if !isNumeric(ctx.FieldValue) {
+		value := ctx.numberValue()
+		if !isNumeric(value) {
 			return false, fmt.Errorf("%s must be a numeric value", ctx.FieldName)
 		}
+		if strict {
+			if err := strictNumberError(ctx, value, "a numeric value"); err != nil {
+				return false, err
+			}
+		}
 		ctx.memory["numeric"] = true
 		return true, nil
 	}, nil
@@ -28,20 +116,75 @@ func constructNumericRule(_cfg map[string]interface{}, args ...string) (Validati
 
 const integerRegex = `^-?\d+$`
 
-var integerRegexp *regexp.Regexp
+var integerRegexp = regexp.MustCompile(integerRegex)
 
 func isInteger(str string) bool {
-	if integerRegexp == nil {
-		integerRegexp = regexp.MustCompile(integerRegex)
-	}
 	return integerRegexp.MatchString(str)
 }
 
+var (
+	prefixedIntegerRegexp   = regexp.MustCompile(`^[+-]?0(?:[xX][0-9a-fA-F]+|[oO][0-7]+|[bB][01]+)$`)
+	scientificIntegerRegexp = regexp.MustCompile(`^[+-]?\d+(?:\.\d+)?[eE][+-]?\d+$`)
+)
+
+// parseLenientInteger returns the value of str when it is an integer written in a form only
+// integer:lenient accepts: with a leading "+" ("+42"), as a hexadecimal, octal or binary literal
+// ("0x1A", "0o17", "0b101") or in scientific notation with an integral value ("1e3", "2.5e1").
+// A leading 0 alone does not make an octal literal: "017" is the plain integer 17.
+func parseLenientInteger(str string) (float64, bool) {
+	switch {
+	case strings.HasPrefix(str, "+") && isDigits(str[1:]):
+		return parseNumber(str[1:])
+	case prefixedIntegerRegexp.MatchString(str):
+		n, err := strconv.ParseInt(str, 0, 64)
+		return float64(n), err == nil
+	case scientificIntegerRegexp.MatchString(str):
+		num, err := strconv.ParseFloat(str, 64)
+		return num, err == nil && !math.IsInf(num, 0) && num == math.Trunc(num)
+	}
+	return 0, false
+}
+
+// integer
+// integer:strict
+// integer:lenient
+// The field under validation must be an integer of optionally negative decimal digits. A leading "+",
+// hexadecimal, octal and binary literals and scientific notation fail, as they do for the digits rules
+// and OtherValue.Int, unless lenient mode accepts them (see parseLenientInteger); the size rules then
+// see their value, 26 for "0x1A", when integer:lenient comes first. Strict mode rejects integers
+// given as strings, see strictNumberError. The two modes contradict each other, strict rejecting the
+// very notations lenient accepts, so combining them fails.
 func constructIntergerRule(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	strict, lenient := false, false
+	for _, arg := range args {
+		switch strings.ToLower(strings.TrimSpace(arg)) {
+		case "strict":
+			strict = true
+		case "lenient":
+			lenient = true
+		default:
+			return nil, fmt.Errorf("invalid integer argument: %s", arg)
+		}
+	}
+	if strict && lenient {
+		return nil, fmt.Errorf("integer rule cannot be both strict and lenient")
+	}
 	return func(ctx *ValidationContext) (bool, error) {
-		if !isInteger(ctx.FieldValue) {
+		value := ctx.numberValue()
+		if lenient && !isInteger(value) {
+			num, ok := parseLenientInteger(value)
+			if !ok {
+				return false, fmt.Errorf("%s must be an integer value", ctx.FieldName)
+			}
+			ctx.memory["number"] = num
+		} else if !isInteger(value) {
 			return false, fmt.Errorf("%s must be an integer value", ctx.FieldName)
 		}
+		if strict {
+			if err := strictNumberError(ctx, value, "an integer value"); err != nil {
+				return false, err
+			}
+		}
 		ctx.memory["integer"] = true
 		ctx.memory["numeric"] = true
 		return true, nil
@@ -50,36 +193,51 @@ func constructIntergerRule(_cfg map[string]interface{}, args ...string) (Validat
 
 // decimal:min,max
 // decimal:value
-// The field under validation must be numeric and must contain the specified number of decimal places:
+// decimal:min,max,trim_zeros
+// The field under validation must be a plain decimal number, optionally negative ("-9.99"), with
+// between min and max decimal places (exactly min when max is omitted). Exponent notation fails.
+// Like Laravel, the digits written count, so "9.90" has 2 decimal places; with trim_zeros trailing
+// zeros are ignored and "9.90" satisfies decimal:1.
 func constructDecimalRule(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	trimZeros := false
+	if len(args) > 0 && strings.ToLower(strings.TrimSpace(args[len(args)-1])) == "trim_zeros" {
+		trimZeros = true
+		args = args[:len(args)-1]
+	}
 	min := 0
 	max := 0
 	if len(args) >= 1 {
-		_, err := fmt.Sscanf(args[0], "%d", &min)
+		var err error
+		min, err = strconv.Atoi(strings.TrimSpace(args[0]))
 		max = min
 		if err != nil {
 			return nil, fmt.Errorf("invalid minimum decimal places: %v", err)
 		}
 	}
 	if len(args) == 2 {
-		_, err := fmt.Sscanf(args[1], "%d", &max)
+		var err error
+		max, err = strconv.Atoi(strings.TrimSpace(args[1]))
 		if err != nil {
 			return nil, fmt.Errorf("invalid maximum decimal places: %v", err)
 		}
 	}
+	if len(args) > 2 {
+		return nil, fmt.Errorf("invalid decimal modifier: %s", args[2])
+	}
 	if min < 0 || max < 0 || min > max {
 		return nil, fmt.Errorf("invalid decimal places range")
 	}
 	return func(ctx *ValidationContext) (bool, error) {
-		if _, ok := ctx.memory["numeric"]; !ok && !isNumeric(ctx.FieldValue) {
+		value := ctx.numberValue()
+		if _, ok := ctx.memory["numeric"]; !ok && !isNumeric(value) {
 			return false, fmt.Errorf("%s must be a numeric value", ctx.FieldName)
 		}
 		ctx.memory["numeric"] = true
-		parts := regexp.MustCompile(`\.`).Split(ctx.FieldValue, -1)
-		decimalPlaces := 0
-		if len(parts) == 2 {
-			decimalPlaces = len(parts[1])
+		_, fraction, _ := strings.Cut(value, ".")
+		if trimZeros {
+			fraction = strings.TrimRight(fraction, "0")
 		}
+		decimalPlaces := len(fraction)
 		if decimalPlaces < min {
 			return false, fmt.Errorf("%s must have at least %d decimal places", ctx.FieldName, min)
 		}
@@ -90,10 +248,14 @@ func constructDecimalRule(_cfg map[string]interface{}, args ...string) (Validati
 	}, nil
 }
 
+// digits:value
+// The field under validation must only contain the digits 0-9, exactly value of them. Signs,
+// decimal points and exponents fail. Messages expose the :digits parameter.
 func constructDigitsRule(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
 	value := 0
 	if len(args) == 1 {
-		_, err := fmt.Sscanf(args[0], "%d", &value)
+		var err error
+		value, err = strconv.Atoi(strings.TrimSpace(args[0]))
 		if err != nil {
 			return nil, fmt.Errorf("invalid digit length: %v", err)
 		}
@@ -101,95 +263,96 @@ func constructDigitsRule(_cfg map[string]interface{}, args ...string) (Validatio
 			return nil, fmt.Errorf("digit length must be non-negative")
 		}
 	}
+	params := []string{fmt.Sprintf("digits=%d", value)}
 	return func(ctx *ValidationContext) (bool, error) {
-		if _, ok := ctx.memory["integer"]; !ok && !isInteger(ctx.FieldValue) {
-			return false, fmt.Errorf("%s must be an integer value", ctx.FieldName)
+		if !isDigits(ctx.FieldValue) {
+			return false, errorWithParams(params, "%s must only contain digits", ctx.FieldName)
 		}
-		ctx.memory["integer"] = true
-		ctx.memory["numeric"] = true
 		if value > 0 && len(ctx.FieldValue) != value {
-			return false, fmt.Errorf("%s must be exactly %d digits long", ctx.FieldName, value)
+			return false, errorWithParams(params, "%s must be exactly %d digits long", ctx.FieldName, value)
 		}
 		return true, nil
 	}, nil
 }
 
+// min_digits:value
+// The field under validation must only contain the digits 0-9, at least value of them. Messages
+// expose the :min parameter.
 func constructMinDigitsRule(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
-	min := 0
 	if len(args) != 1 {
 		return nil, fmt.Errorf("MinDigits rule requires one parameter")
 	}
-	_, err := fmt.Sscanf(args[0], "%d", &min)
+	min, err := strconv.Atoi(strings.TrimSpace(args[0]))
 	if err != nil {
 		return nil, fmt.Errorf("invalid minimum digit length: %v", err)
 	}
 	if min < 0 {
 		return nil, fmt.Errorf("minimum digit length must be non-negative")
 	}
+	params := []string{fmt.Sprintf("min=%d", min)}
 	return func(ctx *ValidationContext) (bool, error) {
-		if _, ok := ctx.memory["integer"]; !ok && !isInteger(ctx.FieldValue) {
-			return false, fmt.Errorf("%s must be an integer value", ctx.FieldName)
+		if !isDigits(ctx.FieldValue) {
+			return false, errorWithParams(params, "%s must only contain digits", ctx.FieldName)
 		}
-		ctx.memory["integer"] = true
-		ctx.memory["numeric"] = true
 		if len(ctx.FieldValue) < min {
-			return false, fmt.Errorf("%s must be at least %d digits long", ctx.FieldName, min)
+			return false, errorWithParams(params, "%s must be at least %d digits long", ctx.FieldName, min)
 		}
 		return true, nil
 	}, nil
 }
 
+// max_digits:value
+// The field under validation must only contain the digits 0-9, at most value of them. Messages
+// expose the :max parameter.
 func constructMaxDigitsRule(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
-	max := 0
 	if len(args) < 1 {
 		return nil, fmt.Errorf("MaxDigits rule requires one parameter")
 	}
-	_, err := fmt.Sscanf(args[0], "%d", &max)
+	max, err := strconv.Atoi(strings.TrimSpace(args[0]))
 	if err != nil {
 		return nil, fmt.Errorf("invalid maximum digit length: %v", err)
 	}
 	if max < 0 {
 		return nil, fmt.Errorf("maximum digit length must be non-negative")
 	}
+	params := []string{fmt.Sprintf("max=%d", max)}
 	return func(ctx *ValidationContext) (bool, error) {
-		if _, ok := ctx.memory["integer"]; !ok && !isInteger(ctx.FieldValue) {
-			return false, fmt.Errorf("%s must be an integer value", ctx.FieldName)
+		if !isDigits(ctx.FieldValue) {
+			return false, errorWithParams(params, "%s must only contain digits", ctx.FieldName)
 		}
-		ctx.memory["integer"] = true
-		ctx.memory["numeric"] = true
 		if len(ctx.FieldValue) > max {
-			return false, fmt.Errorf("%s must be at most %d digits long", ctx.FieldName, max)
+			return false, errorWithParams(params, "%s must be at most %d digits long", ctx.FieldName, max)
 		}
 		return true, nil
 	}, nil
 }
 
+// digits_between:min,max
+// The field under validation must only contain the digits 0-9, between min and max of them.
+// Messages expose the :min and :max parameters.
 func constructDigitsBetweenRule(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
-	min := 0
-	max := 0
 	if len(args) != 2 {
 		return nil, fmt.Errorf("DigitsBetween rule requires two parameters")
 	}
-	_, err := fmt.Sscanf(args[0], "%d", &min)
+	min, err := strconv.Atoi(strings.TrimSpace(args[0]))
 	if err != nil {
 		return nil, fmt.Errorf("invalid minimum digit length: %v", err)
 	}
-	_, err = fmt.Sscanf(args[1], "%d", &max)
+	max, err := strconv.Atoi(strings.TrimSpace(args[1]))
 	if err != nil {
 		return nil, fmt.Errorf("invalid maximum digit length: %v", err)
 	}
 	if min < 0 || max < 0 || min > max {
 		return nil, fmt.Errorf("invalid digit length range")
 	}
+	params := []string{fmt.Sprintf("min=%d", min), fmt.Sprintf("max=%d", max)}
 	return func(ctx *ValidationContext) (bool, error) {
-		if _, ok := ctx.memory["integer"]; !ok && !isInteger(ctx.FieldValue) {
-			return false, fmt.Errorf("%s must be an integer value", ctx.FieldName)
+		if !isDigits(ctx.FieldValue) {
+			return false, errorWithParams(params, "%s must only contain digits", ctx.FieldName)
 		}
-		ctx.memory["integer"] = true
-		ctx.memory["numeric"] = true
 		length := len(ctx.FieldValue)
 		if length < min || length > max {
-			return false, fmt.Errorf("%s must be between %d and %d digits long", ctx.FieldName, min, max)
+			return false, errorWithParams(params, "%s must be between %d and %d digits long", ctx.FieldName, min, max)
 		}
 		return true, nil
 	}, nil
@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Paths:
+// Path
+// Filename
+
+// characters Windows refuses in file names, used when "filename_forbidden_chars" is not configured
+const defaultFilenameForbiddenChars = `<>:"/\|?*`
+
+var windowsReservedNames = map[string]struct{}{
+	"CON": {}, "PRN": {}, "AUX": {}, "NUL": {},
+	"COM1": {}, "COM2": {}, "COM3": {}, "COM4": {}, "COM5": {}, "COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+	"LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {}, "LPT5": {}, "LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+}
+
+func hasControlChars(str string) bool {
+	for _, r := range str {
+		if r < 0x20 || r == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// path:absolute,relative,clean
+// The field under validation must be a slash separated path without NUL or control characters.
+// absolute requires a leading slash; relative forbids it and rejects paths escaping their base with "..";
+// clean requires the path to already be in path.Clean form (no empty, "." or ".." segments).
+func constructPath(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	absolute, relative, clean := false, false, false
+	for _, arg := range args {
+		switch strings.ToLower(strings.TrimSpace(arg)) {
+		case "absolute":
+			absolute = true
+		case "relative":
+			relative = true
+		case "clean":
+			clean = true
+		default:
+			return nil, fmt.Errorf("invalid path modifier: %s", arg)
+		}
+	}
+	if absolute && relative {
+		return nil, fmt.Errorf("path rule cannot be both absolute and relative")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		p := ctx.FieldValue
+		if p == "" || hasControlChars(p) {
+			return false, fmt.Errorf("the %s field must be a valid path", ctx.FieldName)
+		}
+		if absolute && !path.IsAbs(p) {
+			return false, fmt.Errorf("the %s field must be an absolute path", ctx.FieldName)
+		}
+		if relative {
+			if path.IsAbs(p) {
+				return false, fmt.Errorf("the %s field must be a relative path", ctx.FieldName)
+			}
+			if cleaned := path.Clean(p); cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+				return false, fmt.Errorf("the %s field must not traverse outside its base directory", ctx.FieldName)
+			}
+		}
+		if clean && path.Clean(p) != p {
+			return false, fmt.Errorf("the %s field must be a clean path", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+// filename
+// The field under validation must be a single, safe file name: no path separators or traversal (".", ".."),
+// no NUL or control characters, no reserved Windows device names (CON, NUL, COM1, ...) with or without
+// extension, no trailing dot or space and at most 255 bytes. Forbidden characters default to <>:"/\|?* and
+// can be replaced through the "filename_forbidden_chars" config (string); path separators are always rejected.
+func constructFilename(cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+	forbidden := defaultFilenameForbiddenChars
+	if chars, ok := cfg["filename_forbidden_chars"].(string); ok {
+		forbidden = chars + `/\`
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		name := ctx.FieldValue
+		if name == "" || name == "." || name == ".." || len(name) > 255 || hasControlChars(name) {
+			return false, fmt.Errorf("the %s field must be a valid file name", ctx.FieldName)
+		}
+		if strings.ContainsAny(name, forbidden) {
+			return false, fmt.Errorf("the %s field must not contain any of the following characters: %s", ctx.FieldName, forbidden)
+		}
+		if strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+			return false, fmt.Errorf("the %s field must not end with a dot or a space", ctx.FieldName)
+		}
+		base, _, _ := strings.Cut(name, ".")
+		if _, reserved := windowsReservedNames[strings.ToUpper(strings.TrimSpace(base))]; reserved {
+			return false, fmt.Errorf("the %s field must not be a reserved file name", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+var embeddedPathRules = map[string]RuleConstructor{
+	"path":     constructPath,
+	"filename": constructFilename,
+}
@@ -1,96 +1,195 @@
 package validation
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"unicode/utf8"
+)
+
+// String size modes of the size, min, max and between rules, given as their last argument
+// ('max:10,graphemes'). Strings are measured in bytes by default, which suits database column limits;
+// graphemes counts user-perceived characters, so an emoji with a skin tone counts as 1.
+const (
+	sizeBytes     = "bytes"
+	sizeRunes     = "runes"
+	sizeGraphemes = "graphemes"
+)
 
 func getSize(ctx *ValidationContext) float64 {
-	if ctx.HasNumericRule && (ctx.memory["numeric"] == true || isNumeric(ctx.FieldValue)) {
+	return getSizeIn(ctx, sizeBytes)
+}
+
+// getSizeIn is like getSize with strings measured in the given size mode. Values given as a Sizer to
+// ValidateData or ValidateStruct have the size they report.
+func getSizeIn(ctx *ValidationContext, mode string) float64 {
+	if size, ok := ctx.size(); ok {
+		return size
+	}
+	if n, ok := ctx.arrayLength(); ok {
+		return float64(n)
+	}
+	if ctx.HasNumericRule && (ctx.memory["numeric"] == true || isNumeric(ctx.numberValue())) {
 		ctx.memory["numeric"] = true
-		var num float64
-		fmt.Sscanf(ctx.FieldValue, "%f", &num)
+		return ctx.number()
+	}
+	switch mode {
+	case sizeRunes:
+		return float64(utf8.RuneCountInString(ctx.FieldValue))
+	case sizeGraphemes:
+		return float64(graphemeCount(ctx.FieldValue))
+	}
+	return float64(len(ctx.FieldValue))
+}
+
+// number returns the field value parsed as a float, parsed once per field and run.
+func (ctx *ValidationContext) number() float64 {
+	return ctx.parsed("number", func() interface{} {
+		num, _ := parseNumber(ctx.numberValue())
 		return num
-	} else {
-		return float64(len(ctx.FieldValue))
+	}).(float64)
+}
+
+// sizeError returns the error of a size rule with its params. For an array it is worded in items and
+// carries the "array" variant, so that a custom message keyed "field.rule.array" takes precedence over
+// "field.rule", like the array messages of Laravel.
+func sizeError(ctx *ValidationContext, params []string, arrayFormat string, format string, a ...interface{}) error {
+	if _, ok := ctx.arrayLength(); ok {
+		return &paramError{message: fmt.Sprintf(arrayFormat, a...), params: params, variant: "array"}
+	}
+	return errorWithParams(params, format, a...)
+}
+
+// sizeLimit is a number a size is compared with, given as a rule argument or the value of another field.
+type sizeLimit struct {
+	value float64
+	exact *big.Rat // the decimal number written, nil when it is not one
+}
+
+func parseSizeLimit(str string) (sizeLimit, error) {
+	var limit sizeLimit
+	if _, err := fmt.Sscanf(str, "%f", &limit.value); err != nil {
+		return limit, err
+	}
+	if isNumeric(str) {
+		limit.exact, _ = new(big.Rat).SetString(str)
+	}
+	return limit, nil
+}
+
+// compare returns -1, 0 or +1 as the size of the field, measured like getSizeIn does in mode, is less
+// than, equal to or greater than the limit. A decimal value is compared with a decimal limit exactly
+// rather than through float64, which cannot tell 0.30000000000000001 from 0.3 nor integers beyond
+// 2^53 apart, such as amounts of money in minor units.
+func (l sizeLimit) compare(ctx *ValidationContext, mode string) int {
+	if exact, ok := ctx.exactNumber(); ok && l.exact != nil {
+		return exact.Cmp(l.exact)
+	}
+	return cmp.Compare(getSizeIn(ctx, mode), l.value)
+}
+
+// exactNumber returns the value of the field as an exact number when the size rules compare it by
+// its value and it is written as a decimal number, parsed once per field and run.
+func (ctx *ValidationContext) exactNumber() (*big.Rat, bool) {
+	if _, ok := ctx.size(); ok || !ctx.HasNumericRule {
+		return nil, false
+	}
+	exact, _ := ctx.parsed("exact", func() interface{} {
+		value := ctx.numberValue()
+		if !isNumeric(value) {
+			return (*big.Rat)(nil)
+		}
+		exact, _ := new(big.Rat).SetString(value)
+		return exact
+	}).(*big.Rat)
+	return exact, exact != nil
+}
+
+// cutSizeMode removes the size mode from the end of args, returning bytes when there is none.
+func cutSizeMode(args []string) ([]string, string) {
+	if len(args) > 0 {
+		switch mode := args[len(args)-1]; mode {
+		case sizeBytes, sizeRunes, sizeGraphemes:
+			return args[:len(args)-1], mode
+		}
 	}
+	return args, sizeBytes
 }
 
 func constructSizeRule(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	args, mode := cutSizeMode(args)
 	if len(args) < 1 {
 		return nil, fmt.Errorf("size rule requires a size argument")
 	}
-	var expectedSize float64
-	_, err := fmt.Sscanf(args[0], "%f", &expectedSize)
+	expectedSize, err := parseSizeLimit(args[0])
 	if err != nil {
 		return nil, fmt.Errorf("invalid size argument: %s", args[0])
 	}
 
 	return func(ctx *ValidationContext) (bool, error) {
-		actualSize := getSize(ctx)
-		if actualSize != expectedSize {
-			return false, fmt.Errorf("%s must be %v in size", ctx.FieldName, expectedSize)
+		if expectedSize.compare(ctx, mode) != 0 {
+			return false, sizeError(ctx, []string{"size=" + args[0]}, "the %s field must contain %v items", "%s must be %v in size", ctx.FieldName, expectedSize.value)
 		}
 		return true, nil
 	}, nil
 }
 
 func constructMinRule(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	args, mode := cutSizeMode(args)
 	if len(args) < 1 {
 		return nil, fmt.Errorf("min rule requires a minimum argument")
 	}
-	var minSize float64
-	_, err := fmt.Sscanf(args[0], "%f", &minSize)
+	minSize, err := parseSizeLimit(args[0])
 	if err != nil {
 		return nil, fmt.Errorf("invalid minimum argument: %s", args[0])
 	}
 
 	return func(ctx *ValidationContext) (bool, error) {
-		actualSize := getSize(ctx)
-		if actualSize < minSize {
-			return false, fmt.Errorf("%s must be at least %v in size", ctx.FieldName, minSize)
+		if minSize.compare(ctx, mode) < 0 {
+			return false, sizeError(ctx, []string{"min=" + args[0]}, "the %s field must have at least %v items", "%s must be at least %v in size", ctx.FieldName, minSize.value)
 		}
 		return true, nil
 	}, nil
 }
 
 func constructMaxRule(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	args, mode := cutSizeMode(args)
 	if len(args) < 1 {
 		return nil, fmt.Errorf("max rule requires a maximum argument")
 	}
-	var maxSize float64
-	_, err := fmt.Sscanf(args[0], "%f", &maxSize)
+	maxSize, err := parseSizeLimit(args[0])
 	if err != nil {
 		return nil, fmt.Errorf("invalid maximum argument: %s", args[0])
 	}
 
 	return func(ctx *ValidationContext) (bool, error) {
-		actualSize := getSize(ctx)
-		if actualSize > maxSize {
-			return false, fmt.Errorf("%s must be at most %v in size", ctx.FieldName, maxSize)
+		if maxSize.compare(ctx, mode) > 0 {
+			return false, sizeError(ctx, []string{"max=" + args[0]}, "the %s field must not have more than %v items", "%s must be at most %v in size", ctx.FieldName, maxSize.value)
 		}
 		return true, nil
 	}, nil
 }
 
 func constructBetweenRule(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	args, mode := cutSizeMode(args)
 	if len(args) < 2 {
 		return nil, fmt.Errorf("between rule requires two arguments")
 	}
-	var minSize, maxSize float64
-	_, err := fmt.Sscanf(args[0], "%f", &minSize)
+	minSize, err := parseSizeLimit(args[0])
 	if err != nil {
 		return nil, fmt.Errorf("invalid minimum argument: %s", args[0])
 	}
-	_, err = fmt.Sscanf(args[1], "%f", &maxSize)
+	maxSize, err := parseSizeLimit(args[1])
 	if err != nil {
 		return nil, fmt.Errorf("invalid maximum argument: %s", args[1])
 	}
-	if minSize > maxSize {
+	if minSize.value > maxSize.value {
 		return nil, fmt.Errorf("minimum size cannot be greater than maximum size")
 	}
 
 	return func(ctx *ValidationContext) (bool, error) {
-		actualSize := getSize(ctx)
-		if actualSize < minSize || actualSize > maxSize {
-			return false, fmt.Errorf("%s must be between %v and %v in size", ctx.FieldName, minSize, maxSize)
+		if minSize.compare(ctx, mode) < 0 || maxSize.compare(ctx, mode) > 0 {
+			return false, sizeError(ctx, []string{"min=" + args[0], "max=" + args[1]}, "the %s field must have between %v and %v items", "%s must be between %v and %v in size", ctx.FieldName, minSize.value, maxSize.value)
 		}
 		return true, nil
 	}, nil
@@ -102,15 +201,16 @@ func constructGtRule(_cfg map[string]interface{}, args ...string) (ValidationRul
 	}
 
 	return func(ctx *ValidationContext) (bool, error) {
-		targetField, hasTargetField := ctx.Raw[args[0]]
+		targetField, hasTargetField := ctx.Lookup(args[0])
+		targetField = ctx.validator.numberString(targetField)
 		fieldSize := getSize(ctx)
-		if !hasTargetField && (isNumeric(ctx.FieldValue) && isNumeric(args[0])) {
-			argValue := 0.0
-			_, err := fmt.Sscanf(args[0], "%f", &argValue)
+		if !hasTargetField && (isNumeric(ctx.numberValue()) && isNumeric(args[0])) {
+			limit, err := parseSizeLimit(args[0])
 			if err != nil {
 				return false, fmt.Errorf("invalid gt argument: %s", args[0])
 			}
-			if fieldSize > argValue {
+			argValue := limit.value
+			if limit.compare(ctx, sizeBytes) > 0 {
 				return true, nil
 			} else {
 				return false, fmt.Errorf("%s must be greater than %v", ctx.FieldName, argValue)
@@ -121,16 +221,15 @@ func constructGtRule(_cfg map[string]interface{}, args ...string) (ValidationRul
 			return false, fmt.Errorf("gt argument must be a field name or a numeric value")
 		}
 
-		if ctx.HasNumericRule && isNumeric(ctx.FieldValue) && isNumeric(targetField) {
-			argValue := 0.0
-			_, err := fmt.Sscanf(targetField, "%f", &argValue)
+		if ctx.HasNumericRule && isNumeric(ctx.numberValue()) && isNumeric(targetField) {
+			limit, err := parseSizeLimit(targetField)
 			if err != nil {
 				return false, fmt.Errorf("invalid gt argument: %s", args[0])
 			}
-			if fieldSize > argValue {
+			if limit.compare(ctx, sizeBytes) > 0 {
 				return true, nil
 			} else {
-				return false, fmt.Errorf("%s must be greater than %v", ctx.FieldName, args[0])
+				return false, fmt.Errorf("%s must be greater than %v", ctx.FieldName, ctx.Attribute(args[0]))
 			}
 		}
 		targetSize, err := ctx.GetValue(args[0])
@@ -140,7 +239,7 @@ func constructGtRule(_cfg map[string]interface{}, args ...string) (ValidationRul
 		if fieldSize > targetSize {
 			return true, nil
 		} else {
-			return false, fmt.Errorf("%s must be greater than %s", ctx.FieldName, args[0])
+			return false, fmt.Errorf("%s must be greater than %s", ctx.FieldName, ctx.Attribute(args[0]))
 		}
 	}, nil
 }
@@ -151,15 +250,16 @@ func constructGteRule(_cfg map[string]interface{}, args ...string) (ValidationRu
 	}
 
 	return func(ctx *ValidationContext) (bool, error) {
-		targetField, hasTargetField := ctx.Raw[args[0]]
+		targetField, hasTargetField := ctx.Lookup(args[0])
+		targetField = ctx.validator.numberString(targetField)
 		fieldSize := getSize(ctx)
-		if !hasTargetField && (isNumeric(ctx.FieldValue) && isNumeric(args[0])) {
-			argValue := 0.0
-			_, err := fmt.Sscanf(args[0], "%f", &argValue)
+		if !hasTargetField && (isNumeric(ctx.numberValue()) && isNumeric(args[0])) {
+			limit, err := parseSizeLimit(args[0])
 			if err != nil {
 				return false, fmt.Errorf("invalid gte argument: %s", args[0])
 			}
-			if fieldSize >= argValue {
+			argValue := limit.value
+			if limit.compare(ctx, sizeBytes) >= 0 {
 				return true, nil
 			} else {
 				return false, fmt.Errorf("%s must be greater than or equal to %v", ctx.FieldName, argValue)
@@ -169,16 +269,15 @@ func constructGteRule(_cfg map[string]interface{}, args ...string) (ValidationRu
 		if isNumeric(args[0]) {
 			return false, fmt.Errorf("gte argument must be a field name or a numeric value")
 		}
-		if ctx.HasNumericRule && isNumeric(ctx.FieldValue) && isNumeric(targetField) {
-			argValue := 0.0
-			_, err := fmt.Sscanf(targetField, "%f", &argValue)
+		if ctx.HasNumericRule && isNumeric(ctx.numberValue()) && isNumeric(targetField) {
+			limit, err := parseSizeLimit(targetField)
 			if err != nil {
 				return false, fmt.Errorf("invalid gte argument: %s", args[0])
 			}
-			if fieldSize >= argValue {
+			if limit.compare(ctx, sizeBytes) >= 0 {
 				return true, nil
 			} else {
-				return false, fmt.Errorf("%s must be greater than or equal to %v", ctx.FieldName, args[0])
+				return false, fmt.Errorf("%s must be greater than or equal to %v", ctx.FieldName, ctx.Attribute(args[0]))
 			}
 		}
 		targetSize, err := ctx.GetValue(args[0])
@@ -188,7 +287,7 @@ func constructGteRule(_cfg map[string]interface{}, args ...string) (ValidationRu
 		if fieldSize >= targetSize {
 			return true, nil
 		} else {
-			return false, fmt.Errorf("%s must be greater than or equal to %s", ctx.FieldName, args[0])
+			return false, fmt.Errorf("%s must be greater than or equal to %s", ctx.FieldName, ctx.Attribute(args[0]))
 		}
 	}, nil
 }
@@ -199,15 +298,16 @@ func constructLtRule(_cfg map[string]interface{}, args ...string) (ValidationRul
 	}
 
 	return func(ctx *ValidationContext) (bool, error) {
-		targetField, hasTargetField := ctx.Raw[args[0]]
+		targetField, hasTargetField := ctx.Lookup(args[0])
+		targetField = ctx.validator.numberString(targetField)
 		fieldSize := getSize(ctx)
-		if !hasTargetField && (isNumeric(ctx.FieldValue) && isNumeric(args[0])) {
-			argValue := 0.0
-			_, err := fmt.Sscanf(args[0], "%f", &argValue)
+		if !hasTargetField && (isNumeric(ctx.numberValue()) && isNumeric(args[0])) {
+			limit, err := parseSizeLimit(args[0])
 			if err != nil {
 				return false, fmt.Errorf("invalid lt argument: %s", args[0])
 			}
-			if fieldSize < argValue {
+			argValue := limit.value
+			if limit.compare(ctx, sizeBytes) < 0 {
 				return true, nil
 			} else {
 				return false, fmt.Errorf("%s must be less than %v", ctx.FieldName, argValue)
@@ -217,16 +317,15 @@ func constructLtRule(_cfg map[string]interface{}, args ...string) (ValidationRul
 		if isNumeric(args[0]) {
 			return false, fmt.Errorf("lt argument must be a field name or a numeric value")
 		}
-		if ctx.HasNumericRule && isNumeric(ctx.FieldValue) && isNumeric(targetField) {
-			argValue := 0.0
-			_, err := fmt.Sscanf(targetField, "%f", &argValue)
+		if ctx.HasNumericRule && isNumeric(ctx.numberValue()) && isNumeric(targetField) {
+			limit, err := parseSizeLimit(targetField)
 			if err != nil {
 				return false, fmt.Errorf("invalid lt argument: %s", args[0])
 			}
-			if fieldSize < argValue {
+			if limit.compare(ctx, sizeBytes) < 0 {
 				return true, nil
 			} else {
-				return false, fmt.Errorf("%s must be less than %v", ctx.FieldName, args[0])
+				return false, fmt.Errorf("%s must be less than %v", ctx.FieldName, ctx.Attribute(args[0]))
 			}
 		}
 		targetSize, err := ctx.GetValue(args[0])
@@ -236,7 +335,7 @@ func constructLtRule(_cfg map[string]interface{}, args ...string) (ValidationRul
 		if fieldSize < targetSize {
 			return true, nil
 		} else {
-			return false, fmt.Errorf("%s must be less than %s", ctx.FieldName, args[0])
+			return false, fmt.Errorf("%s must be less than %s", ctx.FieldName, ctx.Attribute(args[0]))
 		}
 	}, nil
 }
@@ -247,15 +346,16 @@ func constructLteRule(_cfg map[string]interface{}, args ...string) (ValidationRu
 	}
 
 	return func(ctx *ValidationContext) (bool, error) {
-		targetField, hasTargetField := ctx.Raw[args[0]]
+		targetField, hasTargetField := ctx.Lookup(args[0])
+		targetField = ctx.validator.numberString(targetField)
 		fieldSize := getSize(ctx)
-		if !hasTargetField && (isNumeric(ctx.FieldValue) && isNumeric(args[0])) {
-			argValue := 0.0
-			_, err := fmt.Sscanf(args[0], "%f", &argValue)
+		if !hasTargetField && (isNumeric(ctx.numberValue()) && isNumeric(args[0])) {
+			limit, err := parseSizeLimit(args[0])
 			if err != nil {
 				return false, fmt.Errorf("invalid lte argument: %s", args[0])
 			}
-			if fieldSize <= argValue {
+			argValue := limit.value
+			if limit.compare(ctx, sizeBytes) <= 0 {
 				return true, nil
 			} else {
 				return false, fmt.Errorf("%s must be less than or equal to %v", ctx.FieldName, argValue)
@@ -265,16 +365,15 @@ func constructLteRule(_cfg map[string]interface{}, args ...string) (ValidationRu
 		if isNumeric(args[0]) {
 			return false, fmt.Errorf("lte argument must be a field name or a numeric value")
 		}
-		if ctx.HasNumericRule && isNumeric(ctx.FieldValue) && isNumeric(targetField) {
-			argValue := 0.0
-			_, err := fmt.Sscanf(targetField, "%f", &argValue)
+		if ctx.HasNumericRule && isNumeric(ctx.numberValue()) && isNumeric(targetField) {
+			limit, err := parseSizeLimit(targetField)
 			if err != nil {
 				return false, fmt.Errorf("invalid lte argument: %s", args[0])
 			}
-			if fieldSize <= argValue {
+			if limit.compare(ctx, sizeBytes) <= 0 {
 				return true, nil
 			} else {
-				return false, fmt.Errorf("%s must be less than or equal to %v", ctx.FieldName, args[0])
+				return false, fmt.Errorf("%s must be less than or equal to %v", ctx.FieldName, ctx.Attribute(args[0]))
 			}
 		}
 		targetSize, err := ctx.GetValue(args[0])
@@ -284,7 +383,7 @@ func constructLteRule(_cfg map[string]interface{}, args ...string) (ValidationRu
 		if fieldSize <= targetSize {
 			return true, nil
 		} else {
-			return false, fmt.Errorf("%s must be less than or equal to %s", ctx.FieldName, args[0])
+			return false, fmt.Errorf("%s must be less than or equal to %s", ctx.FieldName, ctx.Attribute(args[0]))
 		}
 	}, nil
 }
@@ -3,11 +3,14 @@ package validation
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net"
-	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"unicode"
+	"unicode/utf8"
 )
 
 // alpha
@@ -97,7 +100,7 @@ func constructAsciiRule(_cfg map[string]interface{}, _args ...string) (Validatio
 func constructConfirmed(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
 	return func(ctx *ValidationContext) (bool, error) {
 		confirmationField := ctx.FieldName + "_confirmation"
-		if confirmationValue, ok := ctx.Raw[confirmationField]; !ok || confirmationValue != ctx.FieldValue {
+		if confirmationValue, ok := ctx.Lookup(confirmationField); !ok || confirmationValue != ctx.FieldValue {
 			return false, fmt.Errorf("the %s field must be confirmed", ctx.FieldName)
 		}
 		return true, nil
@@ -112,8 +115,173 @@ func constructDifferent(_cfg map[string]interface{}, args ...string) (Validation
 	}
 	otherField := args[0]
 	return func(ctx *ValidationContext) (bool, error) {
-		if otherValue, ok := ctx.Raw[otherField]; ok && otherValue == ctx.FieldValue {
-			return false, fmt.Errorf("the %s field must be different from %s", ctx.FieldName, otherField)
+		if otherValue, ok := ctx.Lookup(otherField); ok && otherValue == ctx.FieldValue {
+			return false, fmt.Errorf("the %s field must be different from %s", ctx.FieldName, ctx.Attribute(otherField))
+		}
+		return true, nil
+	}, nil
+}
+
+// levenshtein returns the number of rune insertions, deletions and substitutions turning a into b.
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// similarity returns how close a and b are, ignoring case, from 0 for nothing in common to 1 for
+// equal strings: 1 minus their Levenshtein distance over the length of the longer one.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(strings.ToLower(a)), []rune(strings.ToLower(b))
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+const defaultSimilarityThreshold = 0.7
+
+// maxSimilarityLength bounds the runes compared by isSimilar, as the Levenshtein distance takes time
+// and memory proportional to the product of the lengths.
+const maxSimilarityLength = 256
+
+// isSimilar reports whether similarity(a, b) reaches threshold. The distance is at least the
+// difference of the lengths, so values whose lengths differ too much are told apart without computing
+// it, e.g. a huge password from a username. Values longer than maxSimilarityLength runes are compared
+// by their first runes.
+func isSimilar(a, b string, threshold float64) bool {
+	la, lb := utf8.RuneCountInString(a), utf8.RuneCountInString(b)
+	longest, shortest := max(la, lb), min(la, lb)
+	if longest > 0 && 1-float64(longest-shortest)/float64(longest) < threshold {
+		return false
+	}
+	return similarity(truncateRunes(a, maxSimilarityLength), truncateRunes(b, maxSimilarityLength)) >= threshold
+}
+
+// truncateRunes returns the first n runes of str.
+func truncateRunes(str string, n int) string {
+	for i := range str {
+		if n == 0 {
+			return str[:i]
+		}
+		n--
+	}
+	return str
+}
+
+// not_similar_to:field
+// not_similar_to:field,threshold
+// The field under validation must not be as similar to field as threshold, between 0 and 1 (0.7 by
+// default), measured by similarity: with the default "JohnSmith1" is too close to "johnsmith" while
+// "correct horse" is not. When field is an email address its local part is compared too, so a password
+// must also differ from "john.smith" in "john.smith@example.com". An empty or missing field passes.
+// Meant for passwords that must not be derived from the username or email.
+func constructNotSimilarTo(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 || len(args) > 2 || strings.TrimSpace(args[0]) == "" {
+		return nil, fmt.Errorf("not_similar_to rule requires a field and an optional threshold")
+	}
+	otherField := strings.TrimSpace(args[0])
+	threshold := defaultSimilarityThreshold
+	if len(args) == 2 {
+		var err error
+		threshold, err = strconv.ParseFloat(strings.TrimSpace(args[1]), 64)
+		if err != nil || threshold <= 0 || threshold > 1 {
+			return nil, fmt.Errorf("invalid not_similar_to threshold: %s", args[1])
+		}
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		otherValue, ok := ctx.Lookup(otherField)
+		if !ok || otherValue == "" {
+			return true, nil
+		}
+		candidates := []string{otherValue}
+		if local, _, found := strings.Cut(otherValue, "@"); found && local != "" {
+			candidates = append(candidates, local)
+		}
+		for _, candidate := range candidates {
+			if isSimilar(ctx.FieldValue, candidate, threshold) {
+				return false, errorWithParams([]string{"other=" + ctx.Attribute(otherField)}, "the %s field is too similar to %s", ctx.FieldName, ctx.Attribute(otherField))
+			}
+		}
+		return true, nil
+	}, nil
+}
+
+// passwordEntropy estimates the strength of a password in bits: its length times the lower of the
+// Shannon entropy of its characters and the entropy of a random choice among the character classes
+// it uses (26 lowercase, 26 uppercase, 10 digits, 33 ASCII symbols, 100 for any other character).
+// Repeated characters ("aaaaaaaa") and small alphabets score low; it is a rough estimate that does not
+// know dictionary words or keyboard patterns.
+func passwordEntropy(password string) float64 {
+	counts := make(map[rune]int)
+	length := 0
+	var lower, upper, digit, symbol, other bool
+	for _, r := range password {
+		counts[r]++
+		length++
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r >= '!' && r <= '~':
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	if length == 0 {
+		return 0
+	}
+	pool := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.used {
+			pool += class.size
+		}
+	}
+	shannon := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(length)
+		shannon -= p * math.Log2(p)
+	}
+	return float64(length) * min(shannon, math.Log2(float64(pool)))
+}
+
+// min_entropy:bits
+// The field under validation must have an estimated strength of at least bits, see passwordEntropy:
+// min_entropy:40 accepts "correct horse battery" and rejects "password1". Messages expose the :min
+// parameter.
+func constructMinEntropy(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("min_entropy rule requires a number of bits")
+	}
+	bits, err := strconv.ParseFloat(strings.TrimSpace(args[0]), 64)
+	if err != nil || bits <= 0 {
+		return nil, fmt.Errorf("invalid min_entropy bits: %s", args[0])
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		if passwordEntropy(ctx.FieldValue) < bits {
+			return false, errorWithParams([]string{"min=" + strings.TrimSpace(args[0])}, "the %s field is too easy to guess", ctx.FieldName)
 		}
 		return true, nil
 	}, nil
@@ -133,93 +301,190 @@ func constructEmail(_cfg map[string]interface{}, _args ...string) (ValidationRul
 	}, nil
 }
 
+// hasPrefixFold reports whether str starts with prefix under Unicode case folding, comparing whole
+// runes so multibyte characters are never split.
+func hasPrefixFold(str string, prefix string) bool {
+	for prefix != "" {
+		if str == "" {
+			return false
+		}
+		p, pSize := utf8.DecodeRuneInString(prefix)
+		r, rSize := utf8.DecodeRuneInString(str)
+		if !strings.EqualFold(string(p), string(r)) {
+			return false
+		}
+		prefix, str = prefix[pSize:], str[rSize:]
+	}
+	return true
+}
+
+// hasSuffixFold is the hasPrefixFold counterpart for suffixes.
+func hasSuffixFold(str string, suffix string) bool {
+	for suffix != "" {
+		if str == "" {
+			return false
+		}
+		p, pSize := utf8.DecodeLastRuneInString(suffix)
+		r, rSize := utf8.DecodeLastRuneInString(str)
+		if !strings.EqualFold(string(p), string(r)) {
+			return false
+		}
+		suffix, str = suffix[:len(suffix)-pSize], str[:len(str)-rSize]
+	}
+	return true
+}
+
 // ends_with:foo,bar,...
 // The field under validation must end with one of the given values.
 func constructEndsWith(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return constructAffixRule("ends_with", "end", strings.HasSuffix, args)
+}
+
+// iends_with:foo,bar,...
+// The field under validation must end with one of the given values, ignoring case ("Report.PDF" ends with ".pdf").
+func constructIEndsWith(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return constructAffixRule("iends_with", "end", hasSuffixFold, args)
+}
+
+// constructAffixRule builds the starts_with and ends_with family, where verb is "start" or "end".
+func constructAffixRule(rule string, verb string, has func(str string, affix string) bool, args []string) (ValidationRule, error) {
 	if len(args) < 1 {
-		return nil, fmt.Errorf("ends_with rule requires at least 1 argument")
+		return nil, fmt.Errorf("%s rule requires at least 1 argument", rule)
 	}
 	return func(ctx *ValidationContext) (bool, error) {
-		for _, suffix := range args {
-			if strings.HasSuffix(ctx.FieldValue, suffix) {
+		for _, affix := range args {
+			if has(ctx.FieldValue, affix) {
 				return true, nil
 			}
 		}
-		return false, fmt.Errorf("the %s field must end with one of the following: %s", ctx.FieldName, strings.Join(args, ", "))
-	}, nil
-}
-
-// hex_color
-// The field under validation must contain a valid color value in hexadecimal format.
-func constructHexColor(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
-	return func(ctx *ValidationContext) (bool, error) {
-		hexRegex := regexp.MustCompile(`^#([a-fA-F0-9]{3}|[a-fA-F0-9]{6}|[a-fA-F0-9]{8})$`)
-		if !hexRegex.MatchString(ctx.FieldValue) {
-			return false, fmt.Errorf("the %s field must be a valid hexadecimal color", ctx.FieldName)
-		}
-		return true, nil
+		return false, fmt.Errorf("the %s field must %s with one of the following: %s", ctx.FieldName, verb, strings.Join(args, ", "))
 	}, nil
 }
 
 // in:foo,bar,...
 // The field under validation must be included in the given list of values.
-func constructIn(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
-	if len(args) < 1 {
-		return nil, fmt.Errorf("in rule requires at least 1 argument")
+func constructIn(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return InFunc(nil)(cfg, args...)
+}
+
+// InFunc returns the in rule comparing values with equal instead of string equality, e.g. CaseInsensitive
+// or NumericEqual. Register it under a name: factory.RegisterRule("in_ci", InFunc(CaseInsensitive)).
+func InFunc(equal func(value string, allowed string) bool) RuleConstructor {
+	if equal == nil {
+		equal = func(value string, allowed string) bool { return value == allowed }
 	}
-	return func(ctx *ValidationContext) (bool, error) {
-		for _, allowed := range args {
-			if ctx.FieldValue == allowed {
-				return true, nil
+	return func(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+		if len(args) < 1 {
+			return nil, fmt.Errorf("in rule requires at least 1 argument")
+		}
+		return func(ctx *ValidationContext) (bool, error) {
+			for _, allowed := range args {
+				if equal(ctx.FieldValue, allowed) {
+					return true, nil
+				}
 			}
-		}
-		return false, fmt.Errorf("the %s field must be one of the following: %s", ctx.FieldName, strings.Join(args, ", "))
-	}, nil
+			return false, fmt.Errorf("the %s field must be one of the following: %s", ctx.FieldName, strings.Join(args, ", "))
+		}, nil
+	}
 }
 
-// json
-// The field under validation must be a valid constructJSON string.
-func constructJSON(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
-	return func(ctx *ValidationContext) (bool, error) {
-		var js interface{}
-		if err := json.Unmarshal([]byte(ctx.FieldValue), &js); err != nil {
-			return false, fmt.Errorf("the %s field must be a valid JSON string", ctx.FieldName)
-		}
-		return true, nil
-	}, nil
+// CaseInsensitive reports whether value and allowed are equal under Unicode case folding, for InFunc
+// and NotInFunc.
+func CaseInsensitive(value string, allowed string) bool {
+	return strings.EqualFold(value, allowed)
 }
 
-// ip
-// The field under validation must be an constructIP address.
-func constructIP(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
-	return func(ctx *ValidationContext) (bool, error) {
-		if net.ParseIP(ctx.FieldValue) == nil {
-			return false, fmt.Errorf("the %s field must be a valid IP address", ctx.FieldName)
-		}
-		return true, nil
-	}, nil
+// NumericEqual reports whether value and allowed are equal numbers ("1.50" equals "1.5"), or equal
+// strings when one of them is not numeric, for InFunc and NotInFunc.
+func NumericEqual(value string, allowed string) bool {
+	if isNumeric(value) && isNumeric(allowed) {
+		var a, b float64
+		fmt.Sscanf(value, "%f", &a)
+		fmt.Sscanf(allowed, "%f", &b)
+		return a == b
+	}
+	return value == allowed
 }
 
-// ipv4
-// The field under validation must be an constructIPv4 address.
-func constructIPv4(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+// json:object,array,max_depth=N,max_bytes=N
+// The field under validation must be a valid JSON string. With object or array, the top-level value must be of that type.
+// max_depth limits the nesting of objects and arrays and max_bytes limits the raw length of the string.
+func constructJSON(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	positional, options, err := parseRuleOptions("json", args, "max_depth", "max_bytes")
+	if err != nil {
+		return nil, err
+	}
+	kind := ""
+	for _, arg := range positional {
+		switch arg = strings.ToLower(strings.TrimSpace(arg)); arg {
+		case "object", "array":
+			kind = arg
+		default:
+			return nil, fmt.Errorf("invalid json mode: %s", arg)
+		}
+	}
+	maxDepth, maxBytes := 0, 0
+	if value, ok := options["max_depth"]; ok {
+		if maxDepth, err = strconv.Atoi(value); err != nil || maxDepth <= 0 {
+			return nil, fmt.Errorf("invalid json max_depth: %s", value)
+		}
+	}
+	if value, ok := options["max_bytes"]; ok {
+		if maxBytes, err = strconv.Atoi(value); err != nil || maxBytes <= 0 {
+			return nil, fmt.Errorf("invalid json max_bytes: %s", value)
+		}
+	}
 	return func(ctx *ValidationContext) (bool, error) {
-		if ip := net.ParseIP(ctx.FieldValue); ip == nil || ip.To4() == nil {
-			return false, fmt.Errorf("the %s field must be a valid IPv4 address", ctx.FieldName)
+		if maxBytes > 0 && len(ctx.FieldValue) > maxBytes {
+			return false, fmt.Errorf("the %s field must not be larger than %d bytes", ctx.FieldName, maxBytes)
+		}
+		// checked before decoding so hostile documents are rejected cheaply
+		if maxDepth > 0 && jsonDepthExceeds(ctx.FieldValue, maxDepth) {
+			return false, fmt.Errorf("the %s field must not be nested deeper than %d levels", ctx.FieldName, maxDepth)
+		}
+		if !json.Valid([]byte(ctx.FieldValue)) {
+			return false, fmt.Errorf("the %s field must be a valid JSON string", ctx.FieldName)
+		}
+		trimmed := strings.TrimLeft(ctx.FieldValue, " \t\r\n")
+		if kind == "object" && !strings.HasPrefix(trimmed, "{") {
+			return false, fmt.Errorf("the %s field must be a JSON object", ctx.FieldName)
+		}
+		if kind == "array" && !strings.HasPrefix(trimmed, "[") {
+			return false, fmt.Errorf("the %s field must be a JSON array", ctx.FieldName)
 		}
 		return true, nil
 	}, nil
 }
 
-// ipv6
-// The field under validation must be an constructIPv6 address.
-func constructIPv6(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
-	return func(ctx *ValidationContext) (bool, error) {
-		if ip := net.ParseIP(ctx.FieldValue); ip == nil || ip.To4() != nil {
-			return false, fmt.Errorf("the %s field must be a valid IPv6 address", ctx.FieldName)
+// jsonDepthExceeds reports whether the JSON document nests objects
+// or arrays deeper than max. It stops scanning as soon as the limit is exceeded.
+func jsonDepthExceeds(doc string, max int) bool {
+	depth := 0
+	inString := false
+	for i := 0; i < len(doc); i++ {
+		c := doc[i]
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			if depth > max {
+				return true
+			}
+		case '}', ']':
+			depth--
 		}
-		return true, nil
-	}, nil
+	}
+	return false
 }
 
 // mac_address
@@ -246,65 +511,147 @@ func constructULID(_cfg map[string]interface{}, _args ...string) (ValidationRule
 }
 
 // lowercase
-// The field under validation must be lowercase.
-func constructLowercase(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
-	return func(ctx *ValidationContext) (bool, error) {
-		if ctx.FieldValue != strings.ToLower(ctx.FieldValue) {
-			return false, fmt.Errorf("the %s field must be lowercase", ctx.FieldName)
-		}
-		return true, nil
-	}, nil
+// The field under validation must be a string without uppercase or titlecase characters. Values without
+// cased characters ("123") pass, unless the strict option is given: 'foo' => 'lowercase:strict'
+func constructLowercase(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return constructCaseRule("lowercase", unicode.ToLower, args)
 }
 
 // not_in:foo,bar,...
 // The field under validation must not be included in the given list of values.
-func constructNotIn(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
-	if len(args) < 1 {
-		return nil, fmt.Errorf("not_in rule requires at least 1 argument")
+func constructNotIn(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return NotInFunc(nil)(cfg, args...)
+}
+
+// NotInFunc returns the not_in rule comparing values with equal instead of string equality, see InFunc.
+func NotInFunc(equal func(value string, allowed string) bool) RuleConstructor {
+	if equal == nil {
+		equal = func(value string, disallowed string) bool { return value == disallowed }
 	}
-	return func(ctx *ValidationContext) (bool, error) {
-		for _, disallowed := range args {
-			if ctx.FieldValue == disallowed {
-				return false, fmt.Errorf("the %s field must not be one of the following: %s", ctx.FieldName, strings.Join(args, ", "))
+	return func(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+		if len(args) < 1 {
+			return nil, fmt.Errorf("not_in rule requires at least 1 argument")
+		}
+		return func(ctx *ValidationContext) (bool, error) {
+			for _, disallowed := range args {
+				if equal(ctx.FieldValue, disallowed) {
+					return false, fmt.Errorf("the %s field must not be one of the following: %s", ctx.FieldName, strings.Join(args, ", "))
+				}
 			}
-		}
-		return true, nil
-	}, nil
+			return true, nil
+		}, nil
+	}
 }
 
-// regex:pattern
-// The field under validation must match the given regular expression.
-func constructRegex(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
-	if len(args) < 1 {
-		return nil, fmt.Errorf("regex rule requires 1 argument")
+// normalizeRegexPattern turns a PHP style "/pattern/flags" argument into a Go pattern. The
+// delimiters are the first and the last slash, so slashes inside the pattern need no escaping.
+// Supported flags: i (case-insensitive), m (multi-line), s (dot matches newline), u (no-op, Go
+// patterns are always UTF-8) and x (extended: unescaped whitespace and # comments outside
+// character classes are dropped). Other arguments, such as "/api/v1" (v1 are not flags) or "//"
+// (nothing between the slashes), are Go patterns used as they are. As in PHP, "/api/" is the
+// delimited pattern api; a pattern matching the literal "/api/" is written "/\/api\//" or "^/api/$".
+func normalizeRegexPattern(pattern string) string {
+	lastSlash := strings.LastIndex(pattern, "/")
+	if len(pattern) < 3 || pattern[0] != '/' || lastSlash < 2 {
+		return pattern
 	}
-	pattern := strings.Join(args, ",")
-
-	if len(pattern) >= 2 && pattern[0] == '/' {
-		flags := ""
-		// Find the last '/' to get the pattern without delimiters
-		lastSlash := strings.LastIndex(pattern[1:], "/") + 1
-		if lastSlash > 0 {
-			pattern = pattern[1:lastSlash]
-		}
-		if len(pattern) > lastSlash+1 {
-			flags = pattern[lastSlash+1:]
-		}
-		if strings.Contains(flags, "i") {
-			pattern = "(?i)" + pattern
-		}
-		if strings.Contains(flags, "m") {
-			pattern = "(?m)" + pattern
+	body, flags := pattern[1:lastSlash], pattern[lastSlash+1:]
+	if strings.Trim(flags, "imsux") != "" {
+		return pattern
+	}
+	goFlags := ""
+	for _, flag := range "ims" {
+		if strings.ContainsRune(flags, flag) {
+			goFlags += string(flag)
 		}
-		if strings.Contains(flags, "s") {
-			pattern = "(?s)" + pattern
+	}
+	if strings.ContainsRune(flags, 'x') {
+		body = stripExtendedRegex(body)
+	}
+	if goFlags != "" {
+		body = "(?" + goFlags + ")" + body
+	}
+	return body
+}
+
+// stripExtendedRegex removes the whitespace and comments ignored by the x flag.
+func stripExtendedRegex(pattern string) string {
+	var sb strings.Builder
+	inClass, inComment := false, false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case inComment:
+			inComment = c != '\n'
+		case c == '\\' && i+1 < len(pattern):
+			sb.WriteByte(c)
+			i++
+			sb.WriteByte(pattern[i])
+		case inClass:
+			inClass = c != ']'
+			sb.WriteByte(c)
+		case c == '[':
+			inClass = true
+			sb.WriteByte(c)
+		case c == '#':
+			inComment = true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v':
+			// insignificant whitespace
+		default:
+			sb.WriteByte(c)
 		}
 	}
+	return sb.String()
+}
+
+// maxRegexCacheSize bounds the compiled patterns kept by regexCache, so that rules built from
+// dynamic strings don't grow it without limit.
+const maxRegexCacheSize = 1024
 
+// compiled regex and not_regex patterns, shared by every validator parsing the same pattern. Once
+// the cache is full, an arbitrary entry is dropped for each new pattern.
+var regexCache = struct {
+	mu       sync.Mutex
+	patterns map[string]*regexp.Regexp
+}{patterns: make(map[string]*regexp.Regexp)}
+
+// compileRegexArgs joins the rule arguments back into one pattern, keeping its commas, then
+// normalizes and compiles it.
+func compileRegexArgs(rule string, args []string) (*regexp.Regexp, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%s rule requires 1 argument", rule)
+	}
+	pattern := normalizeRegexPattern(strings.Join(args, ","))
+	regexCache.mu.Lock()
+	re, ok := regexCache.patterns[pattern]
+	regexCache.mu.Unlock()
+	if ok {
+		return re, nil
+	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, fmt.Errorf("invalid regex pattern: %s", pattern)
 	}
+	regexCache.mu.Lock()
+	defer regexCache.mu.Unlock()
+	if len(regexCache.patterns) >= maxRegexCacheSize {
+		for cached := range regexCache.patterns {
+			delete(regexCache.patterns, cached)
+			break
+		}
+	}
+	regexCache.patterns[pattern] = re
+	return re, nil
+}
+
+// regex:pattern
+// The field under validation must match the given regular expression, either a Go pattern or a
+// PHP style "/pattern/flags" (see normalizeRegexPattern). Commas in the pattern are kept.
+func constructRegex(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	re, err := compileRegexArgs("regex", args)
+	if err != nil {
+		return nil, err
+	}
 	return func(ctx *ValidationContext) (bool, error) {
 		if !re.MatchString(ctx.FieldValue) {
 			return false, fmt.Errorf("the %s field format is invalid", ctx.FieldName)
@@ -314,15 +661,11 @@ func constructRegex(_cfg map[string]interface{}, args ...string) (ValidationRule
 }
 
 // not_regex:pattern
-// The field under validation must not match the given regular expression.
+// The field under validation must not match the given regular expression, written like for regex.
 func constructNotRegex(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
-	if len(args) < 1 {
-		return nil, fmt.Errorf("not_regex rule requires 1 argument")
-	}
-	pattern := args[0]
-	re, err := regexp.Compile(pattern)
+	re, err := compileRegexArgs("not_regex", args)
 	if err != nil {
-		return nil, fmt.Errorf("invalid regex pattern: %s", pattern)
+		return nil, err
 	}
 	return func(ctx *ValidationContext) (bool, error) {
 		if re.MatchString(ctx.FieldValue) {
@@ -340,8 +683,8 @@ func constructSame(_cfg map[string]interface{}, args ...string) (ValidationRule,
 	}
 	otherField := args[0]
 	return func(ctx *ValidationContext) (bool, error) {
-		if otherValue, ok := ctx.Raw[otherField]; !ok || otherValue != ctx.FieldValue {
-			return false, fmt.Errorf("the %s field must be the same as %s", ctx.FieldName, otherField)
+		if otherValue, ok := ctx.Lookup(otherField); !ok || otherValue != ctx.FieldValue {
+			return false, fmt.Errorf("the %s field must be the same as %s", ctx.FieldName, ctx.Attribute(otherField))
 		}
 		return true, nil
 	}, nil
@@ -350,60 +693,65 @@ func constructSame(_cfg map[string]interface{}, args ...string) (ValidationRule,
 // starts_with:foo,bar,...
 // The field under validation must start with one of the given values.
 func constructStartsWith(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
-	if len(args) < 1 {
-		return nil, fmt.Errorf("starts_with rule requires at least 1 argument")
-	}
-	return func(ctx *ValidationContext) (bool, error) {
-		for _, prefix := range args {
-			if strings.HasPrefix(ctx.FieldValue, prefix) {
-				return true, nil
-			}
-		}
-		return false, fmt.Errorf("the %s field must start with one of the following: %s", ctx.FieldName, strings.Join(args, ", "))
-	}, nil
+	return constructAffixRule("starts_with", "start", strings.HasPrefix, args)
+}
+
+// istarts_with:foo,bar,...
+// The field under validation must start with one of the given values, ignoring case ("ÉCOLE-1" starts with "école").
+func constructIStartsWith(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return constructAffixRule("istarts_with", "start", hasPrefixFold, args)
 }
 
 // string
 // The field under validation must be a string.
-func constructString(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+func constructString(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	convert := false
+	for _, arg := range args {
+		if arg != "convert" {
+			return nil, fmt.Errorf("string rule has unknown option %q", arg)
+		}
+		convert = true
+	}
 	return func(ctx *ValidationContext) (bool, error) {
-		// In Go, all fields are strings, so always pass
-		return true, nil
+		if ctx.Type == TypeString || (convert && ctx.Type == TypeStringer) {
+			return true, nil
+		}
+		return false, fmt.Errorf("the %s field must be a string", ctx.FieldName)
 	}, nil
 }
 
 // uppercase
-// The field under validation must be uppercase.
-func constructUppercase(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
-	return func(ctx *ValidationContext) (bool, error) {
-		if ctx.FieldValue != strings.ToUpper(ctx.FieldValue) {
-			return false, fmt.Errorf("the %s field must be uppercase", ctx.FieldName)
-		}
-		return true, nil
-	}, nil
+// The field under validation must be a string without lowercase or titlecase characters. Values without
+// cased characters ("123") pass, unless the strict option is given: 'foo' => 'uppercase:strict'
+func constructUppercase(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return constructCaseRule("uppercase", unicode.ToUpper, args)
 }
 
-// url
-// The field under validation must be a valid constructURL.
-func constructURL(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
-	schemes := []string{"http", "https"}
-	if len(args) > 0 {
-		schemes = args
+// constructCaseRule builds the uppercase and lowercase rules, where toCase maps a rune to the expected
+// case. A rune is cased when it has another case mapping, so letters such as "ǅ" (titlecase) fail both.
+func constructCaseRule(rule string, toCase func(r rune) rune, args []string) (ValidationRule, error) {
+	strict := false
+	for _, arg := range args {
+		if arg != "strict" {
+			return nil, fmt.Errorf("%s rule has unknown option %q", rule, arg)
+		}
+		strict = true
 	}
 	return func(ctx *ValidationContext) (bool, error) {
-		u, err := url.Parse(ctx.FieldValue)
-		if err != nil {
-			return false, fmt.Errorf("the %s field must be a valid URL", ctx.FieldName)
-		}
-		validScheme := false
-		for _, scheme := range schemes {
-			if u.Scheme == scheme {
-				validScheme = true
-				break
+		if ctx.Type != TypeString && ctx.Type != TypeStringer {
+			return false, fmt.Errorf("the %s field must be a string", ctx.FieldName)
+		}
+		cased := false
+		for _, r := range ctx.FieldValue {
+			if toCase(r) != r {
+				return false, fmt.Errorf("the %s field must be %s", ctx.FieldName, rule)
+			}
+			if !cased && (unicode.ToUpper(r) != r || unicode.ToLower(r) != r || unicode.IsTitle(r)) {
+				cased = true
 			}
 		}
-		if !validScheme {
-			return false, fmt.Errorf("the %s field must be a valid URL", ctx.FieldName)
+		if strict && !cased {
+			return false, fmt.Errorf("the %s field must contain %s letters", ctx.FieldName, rule)
 		}
 		return true, nil
 	}, nil
@@ -463,6 +811,105 @@ func constructUUID(_cfg map[string]interface{}, args ...string) (ValidationRule,
 	}, nil
 }
 
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// slug
+// The field under validation must be lowercase letters and digits separated by single hyphens, without leading or trailing hyphens.
+func constructSlug(_cfg map[string]interface{}, _args ...string) (ValidationRule, error) {
+	return func(ctx *ValidationContext) (bool, error) {
+		if !slugRegexp.MatchString(ctx.FieldValue) {
+			return false, fmt.Errorf("the %s field must be a valid slug", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+// charsetClass turns a handle charset such as "a-z0-9_" into the body of a regular expression character
+// class. A "-" between two characters makes a range; any other character, "]", "\", "^" or a "-" at
+// either end included, stands for itself.
+func charsetClass(charset string) (string, error) {
+	quote := func(r rune) string {
+		if strings.ContainsRune(`\[]^-`, r) {
+			return `\` + string(r)
+		}
+		return string(r)
+	}
+	runes := []rune(charset)
+	var sb strings.Builder
+	for i := 0; i < len(runes); i++ {
+		if i+2 < len(runes) && runes[i+1] == '-' {
+			if runes[i] > runes[i+2] {
+				return "", fmt.Errorf("invalid handle charset range: %c-%c", runes[i], runes[i+2])
+			}
+			sb.WriteString(quote(runes[i]) + "-" + quote(runes[i+2]))
+			i += 2
+			continue
+		}
+		sb.WriteString(quote(runes[i]))
+	}
+	return sb.String(), nil
+}
+
+// handle:min=3,max=30,charset=a-z0-9_
+// The field under validation must be a user-facing identifier made only of the charset (characters and
+// ranges such as a-z, a-zA-Z0-9_ by default, see charsetClass) with a length between min and max characters.
+// Reserved words are read from the "handle_reserved" config ([]string) and rejected case-insensitively:
+// factory.SetConfig("handle_reserved", []string{"admin", "root"})
+func constructHandle(cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	positional, options, err := parseRuleOptions("handle", args, "min", "max", "charset")
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) > 0 {
+		return nil, fmt.Errorf("invalid handle argument: %s", positional[0])
+	}
+	min, max := 1, 0
+	if value, ok := options["min"]; ok {
+		if min, err = strconv.Atoi(value); err != nil || min < 1 {
+			return nil, fmt.Errorf("invalid handle min length: %s", value)
+		}
+	}
+	if value, ok := options["max"]; ok {
+		if max, err = strconv.Atoi(value); err != nil || max < min {
+			return nil, fmt.Errorf("invalid handle max length: %s", value)
+		}
+	}
+	charset := "a-zA-Z0-9_"
+	if value, ok := options["charset"]; ok && value != "" {
+		charset = value
+	}
+	class, err := charsetClass(charset)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile("^[" + class + "]*$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid handle charset: %s", charset)
+	}
+	var reserved []string
+	if words, ok := cfg["handle_reserved"].([]string); ok {
+		reserved = words
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		length := utf8.RuneCountInString(ctx.FieldValue)
+		if length < min || (max > 0 && length > max) {
+			if max > 0 {
+				return false, fmt.Errorf("the %s field must be between %d and %d characters", ctx.FieldName, min, max)
+			}
+			return false, fmt.Errorf("the %s field must be at least %d characters", ctx.FieldName, min)
+		}
+		if !re.MatchString(ctx.FieldValue) {
+			return false, fmt.Errorf("the %s field contains invalid characters", ctx.FieldName)
+		}
+		for _, word := range reserved {
+			if strings.EqualFold(ctx.FieldValue, word) {
+				return false, fmt.Errorf("the %s field is reserved", ctx.FieldName)
+			}
+		}
+		return true, nil
+	}, nil
+}
+
 var embeddedStringRules = map[string]RuleConstructor{
 	"alpha":             constructAlphaRule,
 	"alpha_dash":        constructAlphaDashRule,
@@ -470,15 +917,15 @@ var embeddedStringRules = map[string]RuleConstructor{
 	"ascii":             constructAsciiRule,
 	"confirmed":         constructConfirmed,
 	"different":         constructDifferent,
+	"not_similar_to":    constructNotSimilarTo,
+	"min_entropy":       constructMinEntropy,
 	"doesnt_end_with":   constructDoesntEndWith,
 	"doesnt_start_with": constructDoesntStartWith,
 	"email":             constructEmail,
 	"ends_with":         constructEndsWith,
-	"hex_color":         constructHexColor,
+	"iends_with":        constructIEndsWith,
+	"istarts_with":      constructIStartsWith,
 	"in":                constructIn,
-	"ip":                constructIP,
-	"ipv4":              constructIPv4,
-	"ipv6":              constructIPv6,
 	"json":              constructJSON,
 	"lowercase":         constructLowercase,
 	"mac_address":       constructMACAddress,
@@ -486,10 +933,12 @@ var embeddedStringRules = map[string]RuleConstructor{
 	"regex":             constructRegex,
 	"not_regex":         constructNotRegex,
 	"same":              constructSame,
+	"slug":              constructSlug,
+	"handle":            constructHandle,
 	"starts_with":       constructStartsWith,
+	"not_in_blocklist":  constructNotInBlocklist,
 	"string":            constructString,
 	"ulid":              constructULID,
 	"uppercase":         constructUppercase,
-	"url":               constructURL,
 	"uuid":              constructUUID,
 }
@@ -2,21 +2,66 @@ package validation
 
 import (
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
+	"time"
 )
 
+// IsEmptyValue reports whether v counts as empty for the presence rules: nil, strings made only of
+// whitespace, empty slices, arrays and maps, the zero time.Time, and nil pointers or interfaces.
+// Non-nil pointers are dereferenced, so a *string pointing to "" is empty too.
+func IsEmptyValue(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(value) == ""
+	case time.Time:
+		return value.IsZero()
+	case *time.Time:
+		return value == nil || value.IsZero()
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(rv.String()) == ""
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return true
+		}
+		return IsEmptyValue(rv.Elem().Interface())
+	}
+	return false
+}
+
+// IsPresent reports whether field exists in data, even if its value is empty.
+func IsPresent[V any](data map[string]V, field string) bool {
+	_, ok := data[field]
+	return ok
+}
+
+// Nullable lets the field be null or empty: the validator then skips the other rules of the field except
+// the implicit ones such as required. The rule itself does nothing.
 func Nullable(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
 	return func(ctx *ValidationContext) (bool, error) {
-		if strings.TrimSpace(ctx.FieldValue) == "" {
-			return false, nil
-		}
+		return true, nil
+	}, nil
+}
+
+// Bail makes ValidateAll stop validating the field at its first failing rule. The rule itself does
+// nothing, Validate always stops at the first failure.
+func Bail(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return func(ctx *ValidationContext) (bool, error) {
 		return true, nil
 	}, nil
 }
 
 func Required(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
 	return func(ctx *ValidationContext) (bool, error) {
-		if strings.TrimSpace(ctx.FieldValue) == "" {
+		if ctx.isEmpty() {
 			return false, fmt.Errorf("%s is required", ctx.FieldName)
 		}
 		return true, nil
@@ -25,15 +70,431 @@ func Required(_cfg map[string]interface{}, args ...string) (ValidationRule, erro
 
 func Missing(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
 	return func(ctx *ValidationContext) (bool, error) {
-		if ctx.FieldValue != "" {
-			return false, fmt.Errorf("%s must be missing", ctx.FieldName)
+		return missingCheck(ctx, true, "")
+	}, nil
+}
+
+// missingCheck fails when triggered and the field is present, even empty, mentioning reason in the
+// message, like requiredCheck does for required.
+func missingCheck(ctx *ValidationContext, triggered bool, reason string) (bool, error) {
+	if !triggered || !ctx.isPresent() {
+		return true, nil
+	}
+	if reason == "" {
+		return false, fmt.Errorf("%s must be missing", ctx.FieldName)
+	}
+	return false, fmt.Errorf("%s must be missing %s", ctx.FieldName, reason)
+}
+
+// Prohibits requires the other fields to be missing or empty when the field is filled, like Laravel.
+// An empty field passes whatever the other fields hold.
+// prohibits:foo,bar,...
+func Prohibits(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("prohibits rule requires at least 1 argument")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		if ctx.isEmpty() {
+			return true, nil
+		}
+		for _, other := range args {
+			if value, _ := ctx.Lookup(other); !IsEmptyValue(value) {
+				return false, fmt.Errorf("%s prohibits %s from being present", ctx.FieldName, attributeList(ctx, args))
+			}
 		}
 		return true, nil
 	}, nil
 }
 
+// MissingIf requires the field to be missing when another field equals one of the values.
+// missing_if:anotherfield,value,...
+func MissingIf(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("missing_if rule requires at least 2 arguments")
+	}
+	other, values := args[0], args[1:]
+	return func(ctx *ValidationContext) (bool, error) {
+		return missingCheck(ctx, fieldEquals(ctx, other, values), fmt.Sprintf("when %s is %s", ctx.Attribute(other), strings.Join(values, ", ")))
+	}, nil
+}
+
+// MissingUnless requires the field to be missing unless another field equals one of the values.
+// missing_unless:anotherfield,value,...
+func MissingUnless(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("missing_unless rule requires at least 2 arguments")
+	}
+	other, values := args[0], args[1:]
+	return func(ctx *ValidationContext) (bool, error) {
+		return missingCheck(ctx, !fieldEquals(ctx, other, values), fmt.Sprintf("unless %s is %s", ctx.Attribute(other), strings.Join(values, ", ")))
+	}, nil
+}
+
+// MissingWith requires the field to be missing when any of the other fields is filled.
+// missing_with:foo,bar,...
+func MissingWith(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("missing_with rule requires at least 1 argument")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		triggered := false
+		for _, other := range args {
+			if value, _ := ctx.Lookup(other); !IsEmptyValue(value) {
+				triggered = true
+				break
+			}
+		}
+		return missingCheck(ctx, triggered, "when "+attributeList(ctx, args)+" is present")
+	}, nil
+}
+
+// MissingWithAll requires the field to be missing when all of the other fields are filled.
+// missing_with_all:foo,bar,...
+func MissingWithAll(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("missing_with_all rule requires at least 1 argument")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		triggered := true
+		for _, other := range args {
+			if value, _ := ctx.Lookup(other); IsEmptyValue(value) {
+				triggered = false
+				break
+			}
+		}
+		return missingCheck(ctx, triggered, "when "+attributeList(ctx, args)+" are present")
+	}, nil
+}
+
+// Sometimes stops the validation of a field when it is not present in the input at all.
+// A present but empty field is still validated by the following rules.
+func Sometimes(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return func(ctx *ValidationContext) (bool, error) {
+		if !ctx.isPresent() {
+			return false, nil
+		}
+		return true, nil
+	}, nil
+}
+
+// Filled requires the field to be non-empty when it is present; a missing field passes.
+// With wildcard fields such as "items.*.name", every present but empty element fails.
+func Filled(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return func(ctx *ValidationContext) (bool, error) {
+		if !ctx.isPresent() {
+			return false, nil
+		}
+		if ctx.isEmpty() {
+			return false, fmt.Errorf("%s must have a value", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+// The present_* rules only look at key existence: a trigger field counts as present even when it is
+// empty, and the field under validation satisfies them when its key exists, whatever its value.
+// The required_* rules look at emptiness instead: a trigger must be filled, and so must the field.
+// When a conditional rule is not triggered and the field is absent (present_*) or empty (required_*),
+// the remaining rules of the field are skipped.
+
+func presentCheck(ctx *ValidationContext, triggered bool, reason string) (bool, error) {
+	if ctx.isPresent() {
+		return true, nil
+	}
+	if triggered {
+		return false, fmt.Errorf("%s must be present %s", ctx.FieldName, reason)
+	}
+	return false, nil
+}
+
+func requiredCheck(ctx *ValidationContext, triggered bool, reason string) (bool, error) {
+	if !ctx.isEmpty() {
+		return true, nil
+	}
+	if triggered {
+		return false, fmt.Errorf("%s is required %s", ctx.FieldName, reason)
+	}
+	return false, nil
+}
+
+// attributeList joins the display names of the given fields for use in messages.
+func attributeList(ctx *ValidationContext, fields []string) string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = ctx.Attribute(field)
+	}
+	return strings.Join(names, " / ")
+}
+
+// fieldEquals reports whether another field is present and equals one of values. Like in Laravel,
+// booleans given to ValidateData match "true" and "1" or "false" and "0", and null matches "null".
+func fieldEquals(ctx *ValidationContext, field string, values []string) bool {
+	other := ctx.OtherValue(field)
+	if !other.Present {
+		return false
+	}
+	for _, v := range values {
+		switch {
+		case other.Value == v:
+			return true
+		case other.Type == TypeBoolean && booleanArg(v) == other.Value:
+			return true
+		case other.Type == TypeNull && v == "null":
+			return true
+		}
+	}
+	return false
+}
+
+// booleanArg returns "true" for the rule arguments "true" and "1", "false" for "false" and "0", and ""
+// otherwise, to compare them with booleans formatted by ValidateData.
+func booleanArg(arg string) string {
+	switch arg {
+	case "true", "1":
+		return "true"
+	case "false", "0":
+		return "false"
+	}
+	return ""
+}
+
+// Present requires the field key to exist in the input; it may be empty.
+func Present(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return func(ctx *ValidationContext) (bool, error) {
+		if !ctx.isPresent() {
+			return false, fmt.Errorf("%s must be present", ctx.FieldName)
+		}
+		return true, nil
+	}, nil
+}
+
+// PresentIf requires the field to be present when anotherfield equals any of the values.
+// present_if:anotherfield,value,...
+func PresentIf(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("present_if rule requires at least 2 arguments")
+	}
+	other, values := args[0], args[1:]
+	return func(ctx *ValidationContext) (bool, error) {
+		return presentCheck(ctx, fieldEquals(ctx, other, values), fmt.Sprintf("when %s is %s", ctx.Attribute(other), strings.Join(values, ", ")))
+	}, nil
+}
+
+// PresentUnless requires the field to be present unless anotherfield equals any of the values.
+// present_unless:anotherfield,value,...
+func PresentUnless(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("present_unless rule requires at least 2 arguments")
+	}
+	other, values := args[0], args[1:]
+	return func(ctx *ValidationContext) (bool, error) {
+		return presentCheck(ctx, !fieldEquals(ctx, other, values), fmt.Sprintf("unless %s is %s", ctx.Attribute(other), strings.Join(values, ", ")))
+	}, nil
+}
+
+// PresentWith requires the field to be present when any of the other fields is present.
+// present_with:foo,bar,...
+func PresentWith(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("present_with rule requires at least 1 argument")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		triggered := false
+		for _, other := range args {
+			if _, ok := ctx.Lookup(other); ok {
+				triggered = true
+				break
+			}
+		}
+		return presentCheck(ctx, triggered, "when "+attributeList(ctx, args)+" is present")
+	}, nil
+}
+
+// PresentWithAll requires the field to be present when all of the other fields are present.
+// present_with_all:foo,bar,...
+func PresentWithAll(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("present_with_all rule requires at least 1 argument")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		triggered := true
+		for _, other := range args {
+			if _, ok := ctx.Lookup(other); !ok {
+				triggered = false
+				break
+			}
+		}
+		return presentCheck(ctx, triggered, "when "+attributeList(ctx, args)+" are present")
+	}, nil
+}
+
+// RequiredIf requires the field to be filled when anotherfield equals any of the values.
+// required_if:anotherfield,value,...
+func RequiredIf(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("required_if rule requires at least 2 arguments")
+	}
+	other, values := args[0], args[1:]
+	return func(ctx *ValidationContext) (bool, error) {
+		return requiredCheck(ctx, fieldEquals(ctx, other, values), fmt.Sprintf("when %s is %s", ctx.Attribute(other), strings.Join(values, ", ")))
+	}, nil
+}
+
+// RequiredIfAccepted requires the field when any of the other fields is accepted ("yes", "on", "1" or
+// "true").
+// required_if_accepted:anotherfield,...
+func RequiredIfAccepted(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return requiredIfOthers("required_if_accepted", isAccepted, "accepted", args)
+}
+
+// RequiredIfDeclined requires the field when any of the other fields is declined ("no", "off", "0" or
+// "false").
+// required_if_declined:anotherfield,...
+func RequiredIfDeclined(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	return requiredIfOthers("required_if_declined", isDeclined, "declined", args)
+}
+
+// requiredIfOthers returns a rule requiring the field when the value of any of the other fields
+// satisfies matches.
+func requiredIfOthers(rule string, matches func(string) bool, state string, others []string) (ValidationRule, error) {
+	if len(others) < 1 {
+		return nil, fmt.Errorf("%s rule requires at least 1 argument", rule)
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		for _, other := range others {
+			if value, ok := ctx.Lookup(other); ok && matches(value) {
+				return requiredCheck(ctx, true, fmt.Sprintf("when %s is %s", ctx.Attribute(other), state))
+			}
+		}
+		return true, nil
+	}, nil
+}
+
+// required_if_context:key,value,...
+// The field under validation must be present and not empty when the context value key, set with
+// Validator.WithContextValue, equals one of the values.
+func RequiredIfContext(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("required_if_context rule requires at least 2 arguments")
+	}
+	key, values := args[0], args[1:]
+	return func(ctx *ValidationContext) (bool, error) {
+		value, ok := ctx.ContextValue(key)
+		return requiredCheck(ctx, ok && slices.Contains(values, value), fmt.Sprintf("when %s is %s", key, strings.Join(values, ", ")))
+	}, nil
+}
+
+// RequiredUnless requires the field to be filled unless anotherfield equals any of the values.
+// required_unless:anotherfield,value,...
+func RequiredUnless(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("required_unless rule requires at least 2 arguments")
+	}
+	other, values := args[0], args[1:]
+	return func(ctx *ValidationContext) (bool, error) {
+		return requiredCheck(ctx, !fieldEquals(ctx, other, values), fmt.Sprintf("unless %s is %s", ctx.Attribute(other), strings.Join(values, ", ")))
+	}, nil
+}
+
+// RequiredWith requires the field to be filled when any of the other fields is filled.
+// required_with:foo,bar,...
+func RequiredWith(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("required_with rule requires at least 1 argument")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		triggered := false
+		for _, other := range args {
+			if value, _ := ctx.Lookup(other); !IsEmptyValue(value) {
+				triggered = true
+				break
+			}
+		}
+		return requiredCheck(ctx, triggered, "when "+attributeList(ctx, args)+" is present")
+	}, nil
+}
+
+// RequiredWithAll requires the field to be filled when all of the other fields are filled.
+// required_with_all:foo,bar,...
+func RequiredWithAll(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("required_with_all rule requires at least 1 argument")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		triggered := true
+		for _, other := range args {
+			if value, _ := ctx.Lookup(other); IsEmptyValue(value) {
+				triggered = false
+				break
+			}
+		}
+		return requiredCheck(ctx, triggered, "when "+attributeList(ctx, args)+" are present")
+	}, nil
+}
+
+// RequiredWithout requires the field to be filled when any of the other fields is empty or missing.
+// required_without:foo,bar,...
+func RequiredWithout(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("required_without rule requires at least 1 argument")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		triggered := false
+		for _, other := range args {
+			if value, _ := ctx.Lookup(other); IsEmptyValue(value) {
+				triggered = true
+				break
+			}
+		}
+		return requiredCheck(ctx, triggered, "when "+attributeList(ctx, args)+" is not present")
+	}, nil
+}
+
+// RequiredWithoutAll requires the field to be filled when all of the other fields are empty or missing.
+// required_without_all:foo,bar,...
+func RequiredWithoutAll(_cfg map[string]interface{}, args ...string) (ValidationRule, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("required_without_all rule requires at least 1 argument")
+	}
+	return func(ctx *ValidationContext) (bool, error) {
+		triggered := true
+		for _, other := range args {
+			if value, _ := ctx.Lookup(other); !IsEmptyValue(value) {
+				triggered = false
+				break
+			}
+		}
+		return requiredCheck(ctx, triggered, "when none of "+attributeList(ctx, args)+" are present")
+	}, nil
+}
+
 var embeddedUtilitiesRules = map[string]RuleConstructor{
-	"nullable": Nullable,
-	"required": Required,
-	"missing":  Missing,
+	"bail":                 Bail,
+	"exclude":              Exclude,
+	"exclude_if":           ExcludeIf,
+	"exclude_unless":       ExcludeUnless,
+	"filled":               Filled,
+	"nullable":             Nullable,
+	"required":             Required,
+	"required_if":          RequiredIf,
+	"required_if_accepted": RequiredIfAccepted,
+	"required_if_declined": RequiredIfDeclined,
+	"required_if_context":  RequiredIfContext,
+	"required_unless":      RequiredUnless,
+	"required_with":        RequiredWith,
+	"required_with_all":    RequiredWithAll,
+	"required_without":     RequiredWithout,
+	"required_without_all": RequiredWithoutAll,
+	"missing":              Missing,
+	"missing_if":           MissingIf,
+	"missing_unless":       MissingUnless,
+	"missing_with":         MissingWith,
+	"missing_with_all":     MissingWithAll,
+	"prohibits":            Prohibits,
+	"present":              Present,
+	"present_if":           PresentIf,
+	"present_unless":       PresentUnless,
+	"present_with":         PresentWith,
+	"present_with_all":     PresentWithAll,
+	"sometimes":            Sometimes,
 }
@@ -0,0 +1,59 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefineRuleSet defines a named combination of rules usable like a rule in the rule strings of
+// validators parsed afterwards, e.g. DefineRuleSet("us_phone", "required|regex:/^\(\d{3}\) \d{3}-\d{4}$/|max:14")
+// makes "phone": "us_phone" or "phone": "sometimes|us_phone" expand to those rules. Rule sets may use
+// other rule sets. The name must not be a rule and the rules must parse.
+func (f *Factory) DefineRuleSet(name string, rules string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if _, ok := f.rules[name]; ok || name == "sanitize" || name == "" || strings.ContainsAny(name, ":|,") {
+		return fmt.Errorf("invalid rule set name %q", name)
+	}
+	previous, redefined := f.ruleSets[name]
+	f.ruleSets[name] = rules
+	if _, err := f.Parse(map[string]string{"rule_set": name}); err != nil {
+		if redefined {
+			f.ruleSets[name] = previous
+		} else {
+			delete(f.ruleSets, name)
+		}
+		return fmt.Errorf("rule set %s: %w", name, err)
+	}
+	return nil
+}
+
+// expandRuleSets replaces the rule sets among rules with their rules, expanding stack being the rule
+// sets being expanded.
+func (f *Factory) expandRuleSets(rules []string, stack []string) ([]string, error) {
+	if len(f.ruleSets) == 0 {
+		return rules, nil
+	}
+	expanded := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		name, _, hasArgs := strings.Cut(rule, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		ruleSet, ok := f.ruleSets[name]
+		if !ok {
+			expanded = append(expanded, rule)
+			continue
+		}
+		if hasArgs {
+			return nil, &ErrParsingRules{Reason: fmt.Sprintf("rule set %s takes no arguments", name)}
+		}
+		if slices.Contains(stack, name) {
+			return nil, &ErrParsingRules{Reason: fmt.Sprintf("rule set %s uses itself", name)}
+		}
+		inner, err := f.expandRuleSets(strings.Split(ruleSet, "|"), append(stack, name))
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, inner...)
+	}
+	return expanded, nil
+}
@@ -0,0 +1,135 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Sanitizer rewrites the value of a field before the rules check it, see the sanitize rule.
+type Sanitizer func(value string) string
+
+// sanitize:trim,lower,...
+// The sanitize rule is not a check: the validator applies its sanitizers in order to the value before
+// any rule of the field runs, wherever sanitize appears in the rules. Other fields referencing the
+// field and the output of Validated see the sanitized value.
+var embeddedSanitizers = map[string]Sanitizer{
+	"trim":  strings.TrimSpace,
+	"ltrim": func(value string) string { return strings.TrimLeftFunc(value, unicode.IsSpace) },
+	"rtrim": func(value string) string { return strings.TrimRightFunc(value, unicode.IsSpace) },
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	// squish trims the value and collapses inner runs of whitespace to a single space.
+	"squish": func(value string) string { return strings.Join(strings.Fields(value), " ") },
+	// nfc, nfd, nfkc and nfkd convert the value to a Unicode normalization form, see NormalizeUnicode.
+	"nfc":  norm.NFC.String,
+	"nfd":  norm.NFD.String,
+	"nfkc": norm.NFKC.String,
+	"nfkd": norm.NFKD.String,
+	// decimal_comma converts a number written with a decimal comma, see decimalComma.
+	"decimal_comma": decimalComma,
+}
+
+// decimalCommaRegexp matches a number written with a decimal comma and optional dots grouping the
+// thousands: "1234,56", "1.234,56", "-1.234.567".
+var decimalCommaRegexp = regexp.MustCompile(`^-?(?:\d+|\d{1,3}(?:\.\d{3})+)(?:,\d+)?$`)
+
+// decimalComma rewrites a number written with a decimal comma, as in German or French locales, to the
+// canonical form the numeric rules and float parsers read: "1.234,56" becomes "1234.56". Other values,
+// such as "1,2,3" or a number already using a decimal point, are returned unchanged for the rules to
+// check, so "12.50" stays 12.50 while "1.250" is read as 1250.
+func decimalComma(value string) string {
+	if !decimalCommaRegexp.MatchString(value) {
+		return value
+	}
+	return strings.Replace(strings.ReplaceAll(value, ".", ""), ",", ".", 1)
+}
+
+// RegisterSanitizer adds a sanitizer usable in the sanitize rule of validators parsed afterwards.
+func (f *Factory) RegisterSanitizer(name string, sanitizer Sanitizer) {
+	f.sanitizers[name] = sanitizer
+}
+
+// NormalizeUnicode makes validators parsed afterwards convert every input value to the given
+// normalization form before the sanitizers and rules run, so that decomposed input ("e" followed by a
+// combining accent) counts as one letter for alpha and the size rules, and same compares visually
+// identical strings as equal. NFC suits most input; NFKC also folds compatibility characters such as
+// full-width letters and ligatures. The nfc, nfd, nfkc and nfkd sanitizers do the same for one field.
+func (f *Factory) NormalizeUnicode(form norm.Form) {
+	f.normalize = form.String
+}
+
+// sanitize returns value with the Unicode normalization of the factory applied to every value and
+// the sanitizers of the rules applied to the present fields. value itself is returned when there is
+// nothing to do, and is never modified.
+func (v *Validator) sanitize(value map[string]string) map[string]string {
+	var sanitized map[string]string
+	if v.normalize != nil {
+		sanitized = make(map[string]string, len(value))
+		for key, item := range value {
+			sanitized[key] = v.normalize(item)
+		}
+		value = sanitized
+	}
+	for _, pattern := range v.fields {
+		sanitizers := v.rules[pattern].Sanitizers
+		if len(sanitizers) == 0 {
+			continue
+		}
+		for _, field := range expandWildcard(pattern, value) {
+			fieldValue, ok := value[field]
+			if !ok {
+				continue
+			}
+			if sanitized == nil {
+				sanitized = make(map[string]string, len(value))
+				for key, item := range value {
+					sanitized[key] = item
+				}
+			}
+			for _, sanitizer := range sanitizers {
+				fieldValue = sanitizer(fieldValue)
+			}
+			sanitized[field] = fieldValue
+		}
+	}
+	if sanitized == nil {
+		return value
+	}
+	return sanitized
+}
+
+// Checkboxes makes the validator treat the given fields, which may contain wildcards, as HTML
+// checkboxes: before the rules run, a checked value ("on", "1", "true" or "yes") becomes "true", and an
+// unchecked one ("off", "0", "false", "no", empty or missing, as browsers leave unchecked boxes out of
+// the form) becomes "false". Other values are left for the rules to reject. Rules such as
+// "boolean|accepted" then work on form posts, and the output of Validated holds real booleans.
+func (v *Validator) Checkboxes(fields ...string) *Validator {
+	v.checkboxes = fields
+	return v
+}
+
+// checkboxValues returns value with the fields of Checkboxes coerced to "true" or "false", leaving value
+// unchanged.
+func (v *Validator) checkboxValues(value map[string]string) map[string]string {
+	if len(v.checkboxes) == 0 {
+		return value
+	}
+	coerced := make(map[string]string, len(value)+len(v.checkboxes))
+	for key, item := range value {
+		coerced[key] = item
+	}
+	for _, pattern := range v.checkboxes {
+		for _, field := range expandWildcard(pattern, value) {
+			switch strings.ToLower(strings.TrimSpace(value[field])) {
+			case "on", "1", "true", "yes":
+				coerced[field] = "true"
+			case "", "off", "0", "false", "no":
+				coerced[field] = "false"
+			}
+		}
+	}
+	return coerced
+}
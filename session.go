@@ -0,0 +1,69 @@
+package validation
+
+import (
+	"context"
+	"maps"
+)
+
+// Session keeps a payload and the errors of its last validation, for interactive workflows such as a
+// REPL or a form wizard correcting fields and validating again. It is not safe for concurrent use;
+// the validator it wraps is.
+type Session struct {
+	validator *Validator
+	data      map[string]string
+	errors    *ErrorBag
+}
+
+// NewSession returns a session validating data with the validator. It is not validated until
+// Revalidate is called.
+func (v *Validator) NewSession(data map[string]string) *Session {
+	return &Session{validator: v, data: maps.Clone(data), errors: &ErrorBag{}}
+}
+
+// Data returns a copy of the current payload.
+func (s *Session) Data() map[string]string {
+	return maps.Clone(s.data)
+}
+
+// SetData replaces the payload and clears the errors of the last validation.
+func (s *Session) SetData(data map[string]string) *Session {
+	s.data = maps.Clone(data)
+	s.errors = &ErrorBag{}
+	return s
+}
+
+// Set changes one field of the payload, e.g. to correct it after a failed validation. The errors of
+// the last validation are kept until Revalidate is called.
+func (s *Session) Set(field string, value string) *Session {
+	if s.data == nil {
+		s.data = make(map[string]string)
+	}
+	s.data[field] = value
+	return s
+}
+
+// Revalidate clears the errors of the last validation and validates the current payload like
+// ValidateAll, keeping the errors for Errors. It returns the ErrorBag when the payload is invalid, or
+// the error rejecting the input as a whole.
+func (s *Session) Revalidate() error {
+	return s.RevalidateContext(context.Background())
+}
+
+// RevalidateContext is like Revalidate but carries ctx to the rules, as ValidateContext does.
+func (s *Session) RevalidateContext(ctx context.Context) error {
+	s.errors = &ErrorBag{}
+	bag, err := s.validator.ValidateAllContext(ctx, s.data)
+	if err != nil {
+		return err
+	}
+	s.errors = bag
+	if bag.IsEmpty() {
+		return nil
+	}
+	return bag
+}
+
+// Errors returns the errors of the last validation, an empty bag before the first one.
+func (s *Session) Errors() *ErrorBag {
+	return s.errors
+}
@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+)
+
+// ValidateStream validates items one at a time, e.g. rows of a large import, so they never need to be
+// held in memory together. next returns the following item and false once exhausted; the validator's
+// rules apply to each item on its own, as with ValidateAll. onError is called with the zero based index
+// of each invalid item and the errors of its fields. It returns the number of invalid items.
+func (v *Validator) ValidateStream(next func() (item map[string]string, ok bool), onError func(index int, errors *ErrorBag)) int {
+	failed, _ := v.ValidateStreamContext(context.Background(), next, onError)
+	return failed
+}
+
+// ValidateStreamContext is like ValidateStream but validates each item with ValidateAllContext. It
+// stops with the context error once ctx is done, and with the error of an item rejected as a whole,
+// e.g. an *ErrLimitExceeded.
+func (v *Validator) ValidateStreamContext(ctx context.Context, next func() (item map[string]string, ok bool), onError func(index int, errors *ErrorBag)) (int, error) {
+	return validateStream(ctx, next, v.ValidateAllContext, onError)
+}
+
+// ValidateDataStream is like ValidateStream for decoded items, such as the objects of a JSON array read
+// with a json.Decoder, each flattened like by ValidateData.
+func (v *Validator) ValidateDataStream(next func() (item map[string]interface{}, ok bool), onError func(index int, errors *ErrorBag)) int {
+	failed, _ := v.ValidateDataStreamContext(context.Background(), next, onError)
+	return failed
+}
+
+// ValidateDataStreamContext is like ValidateDataStream and stops like ValidateStreamContext.
+func (v *Validator) ValidateDataStreamContext(ctx context.Context, next func() (item map[string]interface{}, ok bool), onError func(index int, errors *ErrorBag)) (int, error) {
+	return validateStream(ctx, next, v.validateDataAll, onError)
+}
+
+func validateStream[T any](ctx context.Context, next func() (T, bool), validate func(context.Context, T) (*ErrorBag, error), onError func(index int, errors *ErrorBag)) (int, error) {
+	failed := 0
+	for index := 0; ; index++ {
+		if err := ctx.Err(); err != nil {
+			return failed, err
+		}
+		item, ok := next()
+		if !ok {
+			return failed, nil
+		}
+		bag, err := validate(ctx, item)
+		if err != nil {
+			return failed, fmt.Errorf("item %d: %w", index, err)
+		}
+		if !bag.IsEmpty() {
+			failed++
+			if onError != nil {
+				onError(index, bag)
+			}
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package validation
+
+import "context"
+
+// Tracer starts spans for validation runs, see Validator.ValidateContext. The optional oteltrace
+// module adapts an OpenTelemetry tracer.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is ended with the error of the traced run, nil on success.
+type Span interface {
+	End(err error)
+}
+
+// rules doing I/O, traced with a child span of the validation
+var defaultTracedRules = []string{"exists", "unique"}
+
+// SetTracer sets the tracer used by validators parsed afterwards.
+func (f *Factory) SetTracer(tracer Tracer) {
+	f.tracer = tracer
+}
+
+// TraceRules marks further rules, typically custom rules doing I/O, to be traced with their own span.
+func (f *Factory) TraceRules(names ...string) {
+	f.tracedRules = append(f.tracedRules, names...)
+}
@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// MapKeys renames input keys in the output of Validated, e.g. {"email_address": "email"} to adapt the
+// field names of an external payload to an internal model. Rules keep using the input keys. A key
+// also renames the nested keys under it, so {"addr": "address"} turns "addr.city" into "address.city".
+func (v *Validator) MapKeys(keys map[string]string) *Validator {
+	v.keys = keys
+	return v
+}
+
+// KeepConfirmations keeps the {field}_confirmation counterparts of the fields having the confirmed rule
+// in the output of Validated, which leaves them out by default so that handlers don't persist them.
+func (v *Validator) KeepConfirmations() *Validator {
+	v.keepConfirm = true
+	return v
+}
+
+// Validated validates value like Validate and returns the values of the fields having rules, as changed
+// by their sanitize rule and with the keys renamed by MapKeys. Input keys without rules are left out, as
+// are the {field}_confirmation counterparts of the fields having the confirmed rule, unless
+// KeepConfirmations is set. The output is nil when value is invalid.
+func (v *Validator) Validated(value map[string]string) (map[string]string, error) {
+	return v.ValidatedContext(context.Background(), value)
+}
+
+// ValidatedContext is like Validated but carries ctx to the rules, as ValidateContext does.
+func (v *Validator) ValidatedContext(ctx context.Context, value map[string]string) (map[string]string, error) {
+	sanitized, err := v.run(ctx, value, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return v.validatedData(sanitized), nil
+}
+
+// validatedData returns the present fields of value matched by the rules, renamed with the keys.
+func (v *Validator) validatedData(value map[string]string) map[string]string {
+	validated := make(map[string]string)
+	for _, pattern := range v.fields {
+		for _, field := range expandWildcard(pattern, value) {
+			if fieldValue, ok := value[field]; ok {
+				validated[v.mapKey(field)] = fieldValue
+			}
+		}
+	}
+	if !v.keepConfirm {
+		for _, pattern := range v.fields {
+			if !slices.Contains(v.rules[pattern].RuleNames, "confirmed") {
+				continue
+			}
+			for _, field := range expandWildcard(pattern, value) {
+				delete(validated, v.mapKey(field+"_confirmation"))
+			}
+		}
+	}
+	return validated
+}
+
+// mapKey renames field with the longest key of MapKeys equal to it or to one of its dot prefixes.
+func (v *Validator) mapKey(field string) string {
+	if len(v.keys) == 0 {
+		return field
+	}
+	for prefix := field; ; {
+		if renamed, ok := v.keys[prefix]; ok {
+			return renamed + field[len(prefix):]
+		}
+		dot := strings.LastIndexByte(prefix, '.')
+		if dot < 0 {
+			return field
+		}
+		prefix = prefix[:dot]
+	}
+}
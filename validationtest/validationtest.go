@@ -0,0 +1,58 @@
+// Package validationtest provides assertions for tests of code using validation rules.
+package validationtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shugen002/validation"
+)
+
+func validate(t testing.TB, factory *validation.Factory, data map[string]string, rules map[string]string) *validation.ErrorBag {
+	t.Helper()
+	validator, err := factory.Parse(rules)
+	if err != nil {
+		t.Fatalf("failed to parse rules: %v", err)
+	}
+	bag, err := validator.ValidateAll(data)
+	if err != nil {
+		t.Fatalf("validation rejected the input: %v", err)
+	}
+	return bag
+}
+
+// AssertPasses fails the test when data does not pass rules.
+func AssertPasses(t testing.TB, factory *validation.Factory, data map[string]string, rules map[string]string) {
+	t.Helper()
+	if bag := validate(t, factory, data, rules); !bag.IsEmpty() {
+		t.Errorf("expected validation to pass, got errors: %v", bag.All())
+	}
+}
+
+// AssertFails fails the test when data passes rules, or when one of wantKeys has no error.
+// It returns the error bag for further assertions.
+func AssertFails(t testing.TB, factory *validation.Factory, data map[string]string, rules map[string]string, wantKeys ...string) *validation.ErrorBag {
+	t.Helper()
+	bag := validate(t, factory, data, rules)
+	if bag.IsEmpty() {
+		t.Errorf("expected validation to fail")
+		return bag
+	}
+	for _, key := range wantKeys {
+		if !bag.Has(key) {
+			t.Errorf("expected an error for %s, got errors for: %s", key, strings.Join(bag.Keys(), ", "))
+		}
+	}
+	return bag
+}
+
+// AssertErrorContains fails the test when no message of field contains substr.
+func AssertErrorContains(t testing.TB, bag *validation.ErrorBag, field string, substr string) {
+	t.Helper()
+	for _, message := range bag.Get(field) {
+		if strings.Contains(message, substr) {
+			return
+		}
+	}
+	t.Errorf("expected an error for %s containing %q, got: %q", field, substr, bag.Get(field))
+}
@@ -0,0 +1,22 @@
+package validationtest
+
+import (
+	"testing"
+
+	"github.com/shugen002/validation"
+)
+
+func TestAssertions(t *testing.T) {
+	factory := validation.NewFactory()
+	rules := map[string]string{"email": "required|email", "age": "required|integer|min:18"}
+
+	AssertPasses(t, factory, map[string]string{"email": "ann@example.com", "age": "30"}, rules)
+	bag := AssertFails(t, factory, map[string]string{"email": "nope", "age": "12"}, rules, "email", "age")
+	AssertErrorContains(t, bag, "email", "valid email")
+
+	inner := &testing.T{}
+	AssertFails(inner, factory, map[string]string{"email": "nope", "age": "30"}, rules, "age")
+	if !inner.Failed() {
+		t.Errorf("expected AssertFails to fail when a wanted key has no error")
+	}
+}
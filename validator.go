@@ -1,9 +1,28 @@
 package validation
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
 
 var defaultNumericRules = []string{"numeric", "integer", "int", "decimal"}
 
+// implicitRules still run on the null or empty value of a nullable field when they come before nullable,
+// as they check its presence.
+var implicitRules = []string{
+	"accepted", "accepted_if", "declined", "declined_if", "filled",
+	"missing", "missing_if", "missing_unless", "missing_with", "missing_with_all",
+	"present", "present_if", "present_unless", "present_with", "present_with_all",
+	"required", "required_if", "required_if_accepted", "required_if_context", "required_if_declined", "required_unless",
+	"required_with", "required_with_all", "required_without", "required_without_all", "sometimes",
+	"exclude", "exclude_if", "exclude_unless",
+}
+
 type ValidationContext struct {
 	FieldName      string
 	FieldValue     string
@@ -11,6 +30,17 @@ type ValidationContext struct {
 	HasNumericRule bool
 	Raw            map[string]string
 	memory         map[string]interface{}
+	pattern        string   // rule field the field under validation was expanded from, e.g. "items.*.sku"
+	wildcards      []string // key segments matched by the "*" of the rule field, in order
+	attributes     map[string]string
+	messages       map[string]string
+	validator      *Validator
+	context        context.Context
+	lookups        map[string]string      // other fields looked up by the rule running, for Explain
+	excluded       bool                   // set by the exclude rules
+	types          map[string]string      // types of the input keys, see ValidateData
+	others         map[string]*OtherValue // other fields looked up during the run, see OtherValue
+	sharedValues   map[string]interface{} // values computed once per run for every field, see shared
 	Rules          []string
 	GetValue       func(field string) (float64, error)
 	GetStr         func(field string) (string, error)
@@ -23,58 +53,463 @@ type RuleConstructor func(cfg map[string]interface{}, args ...string) (Validatio
 type ParseResult struct {
 	Rules          []ValidationRule
 	RuleNames      []string
+	RuleArgs       [][]string
 	HasNumericRule bool
+	// Nullable is set when the rules include nullable, see Validator.validateField.
+	Nullable bool
+	// Sanitizers are the sanitizers of the sanitize rule, which is not part of Rules.
+	Sanitizers []Sanitizer
 }
 
 type Validator struct {
-	rules map[string]ParseResult
+	rules       map[string]ParseResult
+	fields      []string // keys of rules, sorted
+	attributes  map[string]string
+	messages    map[string]string
+	metrics     MetricsSink
+	tracer      Tracer
+	traced      []string
+	onFailure   FailureHook
+	onAttribute AttributeHook
+	onRule      RuleHook
+	redact      []string
+	sensitive   []string
+	limits      Limits
+	keys        map[string]string
+	normalize   func(string) string
+	perField    int
+	dedupe      bool
+	values      map[string]string // context values, see WithContextValue
+	inputLength int               // see TruncateInput
+	location    *time.Location    // see InLocation
+	unwrap      bool              // see Factory.UnwrapValues
+	added       []addedValue      // see SetValue
+	keepConfirm bool              // see KeepConfirmations
+	checkboxes  []string          // see Checkboxes
+	trimNumbers bool              // see Factory.TrimNumbers
+}
+
+// SetAttributeNames sets the display names used when error messages mention other fields, e.g.
+// {"start_date": "Start Date"}. Keys may be concrete fields or wildcard fields such as "items.*.min".
+func (v *Validator) SetAttributeNames(names map[string]string) *Validator {
+	v.attributes = names
+	return v
+}
+
+// expandWildcard returns the concrete fields of the inputs matching a rule field such as "items.*.name",
+// where "*" spans one dot separated segment of the input keys: a slice index or a map key, so
+// "translations.*" gives "translations.en" and "translations.fr". Elements whose leaf key is absent
+// are still returned (e.g. "items.1.name" when only "items.1.title" exists) so presence rules can apply.
+func expandWildcard(pattern string, inputs ...map[string]string) []string {
+	patternSegments := strings.Split(pattern, ".")
+	lastWildcard := -1
+	for i, segment := range patternSegments {
+		if segment == "*" {
+			lastWildcard = i
+		}
+	}
+	if lastWildcard < 0 {
+		return []string{pattern}
+	}
+	seen := make(map[string]struct{})
+	fields := []string{}
+	var keys []string
+	for _, input := range inputs {
+		for key := range input {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		keySegments := strings.Split(key, ".")
+		if len(keySegments) <= lastWildcard {
+			continue
+		}
+		concrete := make([]string, len(patternSegments))
+		copy(concrete, patternSegments)
+		matches := true
+		for i := 0; i <= lastWildcard; i++ {
+			if patternSegments[i] == "*" {
+				concrete[i] = keySegments[i]
+			} else if patternSegments[i] != keySegments[i] {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		field := strings.Join(concrete, ".")
+		if _, ok := seen[field]; !ok {
+			seen[field] = struct{}{}
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// matchesWildcard reports whether a concrete field such as "items.0.name" matches a rule field pattern.
+func matchesWildcard(pattern string, field string) bool {
+	patternSegments := strings.Split(pattern, ".")
+	fieldSegments := strings.Split(field, ".")
+	if len(patternSegments) != len(fieldSegments) {
+		return false
+	}
+	for i, segment := range patternSegments {
+		if segment != "*" && segment != fieldSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// wildcardValues returns the segments of field matched by each "*" of pattern.
+func wildcardValues(pattern string, field string) []string {
+	if !strings.Contains(pattern, "*") {
+		return nil
+	}
+	patternSegments := strings.Split(pattern, ".")
+	fieldSegments := strings.Split(field, ".")
+	values := []string{}
+	for i, segment := range patternSegments {
+		if segment == "*" && i < len(fieldSegments) {
+			values = append(values, fieldSegments[i])
+		}
+	}
+	return values
+}
+
+// ruleFor returns the parsed rules applying to a concrete field, resolving wildcard rule fields. When
+// several patterns match, such as "items.*.x" and "items.0.*", the most specific one is used: the one
+// with the fewest "*", then the first in lexical order.
+func (v *Validator) ruleFor(field string) (ParseResult, bool) {
+	if r, ok := v.rules[field]; ok {
+		return r, true
+	}
+	best, wildcards := "", 0
+	for _, pattern := range v.fields {
+		n := strings.Count(pattern, "*")
+		if n > 0 && (best == "" || n < wildcards) && matchesWildcard(pattern, field) {
+			best, wildcards = pattern, n
+		}
+	}
+	if best == "" {
+		return ParseResult{}, false
+	}
+	return v.rules[best], true
 }
 
 func (v *Validator) Validate(value map[string]string) error {
-	for field, rules := range v.rules {
-		ctx := &ValidationContext{
-			FieldName:      field,
-			FieldValue:     value[field],
-			Type:           "string", // assuming string type for simplicity
-			Raw:            value,
-			memory:         make(map[string]interface{}),
-			HasNumericRule: rules.HasNumericRule,
-			Rules:          rules.RuleNames,
-			GetStr: func(f string) (string, error) {
-				if val, exists := value[f]; exists {
-					return val, nil
-				}
-				return "", fmt.Errorf("field %s not found", f)
-			},
-			GetValue: func(f string) (float64, error) {
-				valueStr, exists := value[f]
-				if !exists {
-					return 0, fmt.Errorf("field %s not found", f)
-				}
-				valueHasNumericRule := false
-				if r, ok := v.rules[f]; ok {
-					valueHasNumericRule = r.HasNumericRule
+	return v.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext is like Validate but carries ctx to the rules, see ValidationContext.Context.
+// Input exceeding the factory Limits is rejected with an *ErrLimitExceeded before any rule runs.
+// When the factory has a Tracer, the run is recorded as a "validation" span of ctx, with a child
+// span for each run of an I/O-bound rule (exists, unique and those added with Factory.TraceRules).
+func (v *Validator) ValidateContext(ctx context.Context, value map[string]string) error {
+	_, err := v.run(ctx, value, nil, nil)
+	return err
+}
+
+// ValidateAll validates every field instead of stopping at the first error, and returns the error
+// of each invalid field in an ErrorBag. The error is only set when the input is rejected as a whole,
+// e.g. with an *ErrLimitExceeded.
+func (v *Validator) ValidateAll(value map[string]string) (*ErrorBag, error) {
+	return v.ValidateAllContext(context.Background(), value)
+}
+
+// ValidateAllContext is like ValidateAll but carries ctx to the rules, as ValidateContext does.
+func (v *Validator) ValidateAllContext(ctx context.Context, value map[string]string) (*ErrorBag, error) {
+	bag := &ErrorBag{}
+	if _, err := v.run(ctx, value, nil, bag); err != nil && err != error(bag) {
+		return nil, err
+	}
+	return bag, nil
+}
+
+// run checks the limits, sanitizes value, adds the values of SetValue and validates it, tracing and observing the run. types holds
+// the type of the keys of value given to ValidateData, nil otherwise. With a bag, the errors of all
+// fields are collected into it and the bag is returned when it is not empty. The sanitized value is
+// returned even when it is invalid, unless the limits reject it, without the fields excluded by the
+// exclude rules.
+func (v *Validator) run(ctx context.Context, value map[string]string, types map[string]string, bag *ErrorBag) (sanitized map[string]string, err error) {
+	if err := v.limits.check(value); err != nil {
+		return nil, err
+	}
+	value = v.addValues(v.checkboxValues(v.sanitize(value)))
+	if v.tracer != nil {
+		var span Span
+		ctx, span = v.tracer.Start(ctx, "validation")
+		defer func() { span.End(err) }()
+	}
+	var excluded []string
+	if v.metrics == nil {
+		excluded, err = v.validate(ctx, value, types, nil, bag)
+	} else {
+		start := time.Now()
+		ran := 0
+		excluded, err = v.validate(ctx, value, types, &ran, bag)
+		v.metrics.ObserveValidation(ran, time.Since(start), err != nil)
+	}
+	if len(excluded) > 0 {
+		value = withoutExcluded(value, excluded)
+	}
+	return value, err
+}
+
+// validate runs the rules on value, field by field in sorted order, counting the rules run into ran
+// when it is not nil.
+func (v *Validator) validate(ctx context.Context, value map[string]string, types map[string]string, ran *int, bag *ErrorBag) (excluded []string, err error) {
+	others := make(map[string]*OtherValue)
+	shared := make(map[string]interface{})
+	for _, pattern := range v.fields {
+		rules := v.rules[pattern]
+		// the types also hold the keys of the maps and slices, so an empty element such as
+		// "translations.fr" of {"translations": {"fr": {}}} is still validated
+		for _, field := range expandWildcard(pattern, value, types) {
+			fieldExcluded, err := v.validateField(ctx, pattern, field, rules, value, types, others, shared, ran, bag)
+			if fieldExcluded {
+				excluded = append(excluded, field)
+			}
+			if err != nil && bag == nil {
+				return nil, err
+			}
+		}
+	}
+	if bag != nil && !bag.IsEmpty() {
+		return excluded, bag
+	}
+	return excluded, nil
+}
+
+// validateField runs the rules of one field. When the field is nullable and its value is null or empty,
+// only the implicit rules written before nullable run, so "nullable|required" accepts an empty value
+// (as rule sets in the wild expect) while "required|nullable" does not.
+// Without a bag it stops at the first failing rule and returns its error. With a bag, the message of
+// each failing rule is added to it in declaration order, stopping after a failing implicit rule or
+// when the rules include bail, and the first error is returned. excluded reports whether an exclude
+// rule excluded the field.
+func (v *Validator) validateField(goCtx context.Context, pattern string, field string, rules ParseResult, value map[string]string, types map[string]string, others map[string]*OtherValue, shared map[string]interface{}, ran *int, bag *ErrorBag) (excluded bool, err error) {
+	if v.onAttribute != nil && !v.onAttribute(goCtx, field) {
+		return false, nil
+	}
+	fieldType, ok := types[field]
+	if !ok {
+		fieldType = TypeString
+	}
+	trace := v.traceAttribute(goCtx, field, value)
+	ctx := &ValidationContext{
+		FieldName:      field,
+		FieldValue:     value[field],
+		Type:           fieldType,
+		Raw:            value,
+		memory:         make(map[string]interface{}),
+		HasNumericRule: rules.HasNumericRule,
+		Rules:          rules.RuleNames,
+		pattern:        pattern,
+		wildcards:      wildcardValues(pattern, field),
+		attributes:     v.attributes,
+		messages:       v.messages,
+		validator:      v,
+		context:        goCtx,
+		types:          types,
+		others:         others,
+		sharedValues:   shared,
+	}
+	ctx.GetStr = func(f string) (string, error) {
+		if val, exists := ctx.Lookup(f); exists {
+			return val, nil
+		}
+		return "", fmt.Errorf("field %s not found", f)
+	}
+	ctx.GetValue = func(f string) (float64, error) {
+		other := ctx.OtherValue(f)
+		if !other.Present {
+			return 0, fmt.Errorf("field %s not found", f)
+		}
+		return other.parsed("size", func() interface{} {
+			if r, ok := v.ruleFor(other.Field); ok && r.HasNumericRule {
+				if num, ok := other.Float(); ok {
+					return num
 				}
-				if valueHasNumericRule && isNumeric(valueStr) {
-					var num float64
-					fmt.Sscanf(valueStr, "%f", &num)
-					return num, nil
+			}
+			return float64(len(other.Value))
+		}).(float64), nil
+	}
+	null := rules.Nullable && (fieldType == TypeNull || ctx.isEmpty())
+	nullableAt := slices.Index(rules.RuleNames, "nullable")
+	bail := bag == nil || slices.Contains(rules.RuleNames, "bail")
+	var first error
+	for i := 0; i < len(rules.Rules); i++ {
+		if null && (i > nullableAt || !slices.Contains(implicitRules, rules.RuleNames[i])) {
+			continue
+		}
+		rule := rules.Rules[i]
+		if trace != nil {
+			ctx.lookups = make(map[string]string)
+		}
+		next, err := v.runRule(ctx, rules.RuleNames[i], rule)
+		if ran != nil {
+			*ran++
+		}
+		if err != nil {
+			var withParams *paramError
+			hasParams := errors.As(err, &withParams)
+			message, ok := "", false
+			if hasParams && withParams.variant != "" {
+				message, ok = ctx.customMessage(rules.RuleNames[i] + "." + withParams.variant)
+			}
+			if !ok {
+				message, ok = ctx.customMessage(rules.RuleNames[i])
+			}
+			if ok {
+				if hasParams {
+					message = replaceMessageParams(message, withParams.params)
 				}
-				return float64(len(valueStr)), nil
-			},
-		}
-		for i := 0; i < len(rules.Rules); i++ {
-			rule := rules.Rules[i]
-			next, err := rule(ctx)
-			if err != nil {
-				return err
+				err = errors.New(message)
+			}
+		}
+		if trace != nil {
+			trace.addRule(rules.RuleNames[i], rules.RuleArgs[i], ctx.lookups, err)
+		}
+		if err != nil {
+			if v.onFailure != nil {
+				v.onFailure(goCtx, field, rules.RuleNames[i], v.redactedValue(field, value), err.Error())
+			}
+			if bag != nil && !(v.dedupe && slices.Contains(bag.Get(field), err.Error())) {
+				bag.Add(field, err.Error())
+			}
+			if first == nil {
+				first = err
 			}
-			if !next {
+			if bail || slices.Contains(implicitRules, rules.RuleNames[i]) || (v.perField > 0 && len(bag.Get(field)) >= v.perField) {
 				break
 			}
+			continue
 		}
+		if !next {
+			break
+		}
+	}
+	return ctx.excluded, first
+}
+
+// runRule runs one rule, observing it with the metrics sink and the rule hook and tracing it when it is
+// I/O-bound.
+func (v *Validator) runRule(ctx *ValidationContext, name string, rule ValidationRule) (next bool, err error) {
+	if v.tracer != nil && slices.Contains(v.traced, name) {
+		goCtx, span := v.tracer.Start(ctx.context, "validation.rule."+name)
+		defer func() { span.End(err) }()
+		parent := ctx.context
+		ctx.context = goCtx
+		defer func() { ctx.context = parent }()
+	}
+	if v.metrics == nil && v.onRule == nil {
+		return rule(ctx)
+	}
+	start := time.Now()
+	next, err = rule(ctx)
+	duration := time.Since(start)
+	if v.metrics != nil {
+		v.metrics.ObserveRule(name, duration, err != nil)
+	}
+	if v.onRule != nil {
+		v.onRule(ctx.Context(), ctx.FieldName, name, err == nil, duration)
+	}
+	return next, err
+}
+
+// Context returns the context given to ValidateContext (context.Background for Validate), for rules
+// doing I/O such as exists and unique.
+func (ctx *ValidationContext) Context() context.Context {
+	if ctx.context == nil {
+		return context.Background()
+	}
+	return ctx.context
+}
+
+// Lookup returns the raw value of another field referenced by a rule argument. Nested fields are
+// referenced with their full dot path, e.g. "settings.billing.enabled" or "profile.email".
+// Inside a wildcard group the "*" of the reference are bound to the indexes of the field under
+// validation, so "items.*.max" with "gte:items.*.min" compares items.3.max against items.3.min.
+func (ctx *ValidationContext) Lookup(field string) (string, bool) {
+	resolved := ctx.ResolveField(field)
+	value, ok := ctx.Raw[resolved]
+	if ok && ctx.lookups != nil && resolved != ctx.FieldName {
+		ctx.lookups[resolved] = ctx.validator.redactedValue(resolved, ctx.Raw)
+	}
+	return value, ok
+}
+
+// ResolveField replaces the "*" segments of a field reference with the wildcard indexes of the
+// field under validation. Asterisks beyond the current nesting level are left untouched.
+func (ctx *ValidationContext) ResolveField(field string) string {
+	return bindWildcards(field, ctx.wildcards)
+}
+
+// bindWildcards replaces the "*" segments of field with values, in order. Asterisks beyond values are
+// left untouched.
+func bindWildcards(field string, values []string) string {
+	if len(values) == 0 || !strings.Contains(field, "*") {
+		return field
+	}
+	segments := strings.Split(field, ".")
+	next := 0
+	for i, segment := range segments {
+		if segment == "*" && next < len(values) {
+			segments[i] = values[next]
+			next++
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
+// Attribute returns the display name of a referenced field for use in error messages. The reference
+// is resolved like in Lookup, then matched against the names given to SetAttributeNames, first
+// exactly, then as written and finally against wildcard names. Unnamed fields keep their key.
+func (ctx *ValidationContext) Attribute(field string) string {
+	resolved := ctx.ResolveField(field)
+	if name, ok := ctx.attributes[resolved]; ok {
+		return name
+	}
+	if name, ok := ctx.attributes[field]; ok {
+		return name
+	}
+	for pattern, name := range ctx.attributes {
+		if strings.Contains(pattern, "*") && matchesWildcard(pattern, resolved) {
+			return name
+		}
+	}
+	return resolved
+}
+
+// parsed returns the conversion of the field value cached under key, computing it with parse on the
+// first call, so rules converting the same value (a date for date, after and before, a number for
+// min and max) parse it once per field and run.
+func (ctx *ValidationContext) parsed(key string, parse func() interface{}) interface{} {
+	if value, ok := ctx.memory[key]; ok {
+		return value
+	}
+	value := parse()
+	if ctx.memory != nil {
+		ctx.memory[key] = value
+	}
+	return value
+}
+
+// shared is like parsed for values derived from the whole input rather than the field value, such as
+// the combinations of a wildcard group for distinct_with: they are computed once per run and shared by
+// every field.
+func (ctx *ValidationContext) shared(key string, compute func() interface{}) interface{} {
+	if value, ok := ctx.sharedValues[key]; ok {
+		return value
+	}
+	value := compute()
+	if ctx.sharedValues != nil {
+		ctx.sharedValues[key] = value
 	}
-	return nil
+	return value
 }
 
 func (ctx *ValidationContext) SetMemory(key string, value interface{}) {
@@ -0,0 +1,55 @@
+package validation
+
+import (
+	"maps"
+	"slices"
+)
+
+// addedValue is a field added to the input with SetValue or SetValueFunc.
+type addedValue struct {
+	field   string
+	value   string
+	compute func(data map[string]string) string
+}
+
+// SetValue returns a copy of the validator adding field to the input with value before the rules run,
+// e.g. a value derived by the caller that rules can reference: validator.SetValue("full_name", name)
+// lets "display_name": "different:full_name" see it. The value replaces the one of the input, if any.
+// Like the input fields, it is part of the output of Validated only when rules target the field. The
+// validator itself is left unchanged, as with WithContextValue.
+func (v *Validator) SetValue(field string, value string) *Validator {
+	return v.withAddedValue(addedValue{field: field, value: value})
+}
+
+// SetValueFunc is like SetValue with the value computed from the sanitized input when the validator
+// runs, e.g. SetValueFunc("full_name", func(data map[string]string) string {
+// return data["first_name"] + " " + data["last_name"] }). Values are added in the order they were set,
+// so a function sees the values set before it.
+func (v *Validator) SetValueFunc(field string, compute func(data map[string]string) string) *Validator {
+	return v.withAddedValue(addedValue{field: field, compute: compute})
+}
+
+func (v *Validator) withAddedValue(added addedValue) *Validator {
+	clone := *v
+	clone.added = append(slices.Clip(v.added), added)
+	return &clone
+}
+
+// addValues returns value with the values of SetValue and SetValueFunc added, leaving value unchanged.
+func (v *Validator) addValues(value map[string]string) map[string]string {
+	if len(v.added) == 0 {
+		return value
+	}
+	value = maps.Clone(value)
+	if value == nil {
+		value = make(map[string]string, len(v.added))
+	}
+	for _, added := range v.added {
+		if added.compute != nil {
+			value[added.field] = added.compute(value)
+		} else {
+			value[added.field] = added.value
+		}
+	}
+	return value
+}